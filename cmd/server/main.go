@@ -8,6 +8,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/syst3mctl/check-in-api/internal/adapter/storage/local"
 	"github.com/syst3mctl/check-in-api/internal/adapter/storage/postgres"
 	"github.com/syst3mctl/check-in-api/internal/api/handler"
 	"github.com/syst3mctl/check-in-api/internal/api/middleware"
@@ -15,6 +16,7 @@ import (
 	"github.com/syst3mctl/check-in-api/internal/config"
 	"github.com/syst3mctl/check-in-api/internal/core/service"
 	"github.com/syst3mctl/check-in-api/internal/pkg/logger"
+	"github.com/syst3mctl/check-in-api/internal/pkg/scheduler"
 )
 
 // @title Check-In Service API
@@ -25,6 +27,11 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+
+// @securityDefinitions.apikey KioskAuth
+// @in header
+// @name Authorization
+// @description Kiosk device token as "Kiosk <token>"
 func main() {
 	logger.Init()
 
@@ -46,13 +53,27 @@ func main() {
 	orgRepo := postgres.NewOrgRepository(db)
 	attRepo := postgres.NewAttendanceRepository(db)
 	reportRepo := postgres.NewReportRepository(db)
+	correctionRepo := postgres.NewCorrectionRepository(db)
+	idempotencyRepo := postgres.NewIdempotencyRepository(db)
+	syncRepo := postgres.NewSyncRepository(db)
+	kioskRepo := postgres.NewKioskRepository(db)
+	photoStorage := local.NewFileStorage(cfg.PhotoStorageDir)
 
 	// Services
 	authService := service.NewAuthService(userRepo, cfg)
 	userService := service.NewUserService(userRepo)
 	orgService := service.NewOrgService(orgRepo, userRepo, db)
-	attService := service.NewAttendanceService(attRepo)
+	attService := service.NewAttendanceService(attRepo, orgRepo, db, service.RiskPolicy{
+		MaxAccuracyMeters:     float64(cfg.RiskMaxAccuracyMeters),
+		MaxTravelSpeedKmh:     float64(cfg.RiskMaxTravelSpeedKmh),
+		CoordinateReuseWindow: cfg.RiskCoordinateReuseWindow,
+	})
 	reportService := service.NewReportService(reportRepo)
+	correctionService := service.NewCorrectionService(correctionRepo, attRepo, orgRepo, db)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyKeyTTL)
+	syncService := service.NewSyncService(syncRepo, attService, db, cfg.OfflineEventMaxAge, cfg.MaxClockSkew)
+	kioskService := service.NewKioskService(kioskRepo, attService, cfg.KioskPINSecret, cfg.KioskMaxPINAttempts, cfg.KioskAttemptWindow, cfg.KioskLockout)
+	photoService := service.NewPhotoService(attRepo, attService, photoStorage, int64(cfg.MaxPhotoSize))
 
 	// Handlers
 	authHandler := handler.NewAuthHandler(authService)
@@ -60,18 +81,51 @@ func main() {
 	orgHandler := handler.NewOrgHandler(orgService)
 	attHandler := handler.NewAttendanceHandler(attService)
 	reportHandler := handler.NewReportHandler(reportService)
+	correctionHandler := handler.NewCorrectionHandler(correctionService)
+	syncHandler := handler.NewSyncHandler(syncService)
+	kioskHandler := handler.NewKioskHandler(kioskService)
+	photoHandler := handler.NewPhotoHandler(photoService)
 
 	// Middleware
 	authMiddleware := middleware.NewAuthMiddleware(cfg)
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(idempotencyService)
+	kioskMiddleware := middleware.NewKioskMiddleware(kioskService)
+	clientIPMiddleware, err := middleware.NewClientIPMiddleware(cfg.TrustedProxies)
+	if err != nil {
+		logger.Error("Invalid TRUSTED_PROXIES", "error", err)
+		os.Exit(1)
+	}
 
 	// Router
-	r := router.New(authHandler, userHandler, orgHandler, attHandler, reportHandler, authMiddleware)
+	r := router.New(authHandler, userHandler, orgHandler, attHandler, reportHandler, correctionHandler, syncHandler, kioskHandler, photoHandler, authMiddleware, idempotencyMiddleware, kioskMiddleware, clientIPMiddleware)
 
 	srv := &http.Server{
 		Addr:    ":" + cfg.Port,
 		Handler: r,
 	}
 
+	// Background jobs
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go scheduler.Every(jobCtx, "mark-absences", cfg.AbsenceCheckInterval, func(ctx context.Context) error {
+		created, err := attService.MarkAbsences(ctx, time.Now(), cfg.AbsenceLookback)
+		if created > 0 {
+			logger.Info("Recorded absences", "count", created)
+		}
+		return err
+	})
+	go scheduler.Every(jobCtx, "auto-close-sessions", cfg.AutoCloseInterval, func(ctx context.Context) error {
+		closed, err := attService.AutoCloseSessions(ctx, time.Now(), cfg.AutoCloseAfterShiftEnd, cfg.MaxSessionLength)
+		if closed > 0 {
+			logger.Info("Auto-closed open sessions", "count", closed)
+		}
+		return err
+	})
+	go scheduler.Every(jobCtx, "purge-idempotency-keys", cfg.IdempotencyPurgeInterval, func(ctx context.Context) error {
+		_, err := idempotencyService.PurgeExpired(ctx, time.Now())
+		return err
+	})
+
 	go func() {
 		logger.Info("Starting server", "port", cfg.Port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -85,6 +139,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("Shutting down server...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/attendance/check-in": {
+        "/attendance/breaks/end": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Perform a check-in (General or Task-based)",
+                "description": "End the running break in the open attendance session",
                 "consumes": [
                     "application/json"
                 ],
@@ -32,28 +32,32 @@ const docTemplate = `{
                 "tags": [
                     "Attendance"
                 ],
-                "summary": "Check-in",
+                "summary": "End a break",
                 "parameters": [
                     {
-                        "description": "Check-In Request",
+                        "description": "Organization of the session",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CheckInRequest"
+                            "$ref": "#/definitions/domain.EndBreakRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key for safely retrying the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/domain.AttendanceBreak"
                         }
                     },
                     "400": {
-                        "description": "invalid request body or validation errors",
+                        "description": "not checked in or not on break",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -61,14 +65,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/attendance/check-out": {
+        "/attendance/breaks/start": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Perform a check-out",
+                "description": "Start a break in the open attendance session. Unpaid breaks are subtracted from worked time.",
                 "consumes": [
                     "application/json"
                 ],
@@ -78,19 +82,38 @@ const docTemplate = `{
                 "tags": [
                     "Attendance"
                 ],
-                "summary": "Check-out",
+                "summary": "Start a break",
+                "parameters": [
+                    {
+                        "description": "Organization of the session and break type; both may be omitted",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.StartBreakRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key for safely retrying the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.AttendanceBreak"
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "not checked in or unknown break type",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "already on break",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -98,9 +121,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
+        "/attendance/check-in": {
             "post": {
-                "description": "Login with email and password to get JWT token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Perform a check-in (General or Task-based). A QR token scanned at one of the organization's checkpoints can be sent in place of coordinates for general check-ins.",
                 "consumes": [
                     "application/json"
                 ],
@@ -108,18 +136,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Attendance"
                 ],
-                "summary": "Login user",
+                "summary": "Check-in",
                 "parameters": [
                     {
-                        "description": "Login Request",
+                        "description": "Check-In Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.LoginRequest"
+                            "$ref": "#/definitions/domain.CheckInRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key for safely retrying the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -127,9 +161,7 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -138,8 +170,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "unauthorized",
+                    "403": {
+                        "description": "outside the allowed geofence or networks",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "a concurrent check-in opened a session first, or the QR token was already used",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -147,9 +185,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
+        "/attendance/check-out": {
             "post": {
-                "description": "Get a new access token using a refresh token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Perform a check-out. The location, when given, is checked against the same geofence as the check-in.",
                 "consumes": [
                     "application/json"
                 ],
@@ -157,35 +200,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Attendance"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Check-out",
                 "parameters": [
                     {
-                        "description": "Refresh Token Request",
+                        "description": "Check-Out Request",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.RefreshTokenRequest"
+                            "$ref": "#/definitions/domain.CheckOutRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key for safely retrying the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.TokenPair"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid request body or validation errors",
+                        "description": "bad request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "unauthorized",
+                    "403": {
+                        "description": "outside the allowed geofence",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "attendance not found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -193,9 +248,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/attendance/sync": {
             "post": {
-                "description": "Register a new user with the provided details",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply check-ins and check-outs the device queued while offline, oldest first. Each event is accepted or rejected on its own; accepted events are marked offline, and events captured long ago or from a skewed device clock are flagged. Retried events return their original result.",
                 "consumes": [
                     "application/json"
                 ],
@@ -203,25 +263,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Attendance"
                 ],
-                "summary": "Register a new user",
+                "summary": "Sync offline punches",
                 "parameters": [
                     {
-                        "description": "Register Request",
+                        "description": "Queued events",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.RegisterRequest"
+                            "$ref": "#/definitions/domain.SyncRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key for safely retrying the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.User"
+                            "$ref": "#/definitions/domain.SyncResponse"
                         }
                     },
                     "400": {
@@ -239,9 +305,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/logout": {
+        "/attendance/{attendance_id}/corrections": {
             "post": {
-                "description": "Logout the user (client-side token removal, server-side placeholder)",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ask a manager to change the check-in and/or check-out time of one of your attendance records. A missed shift needs both times.",
                 "consumes": [
                     "application/json"
                 ],
@@ -249,44 +320,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Correction"
                 ],
-                "summary": "Logout user",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Request an attendance correction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Attendance ID",
+                        "name": "attendance_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Correction Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.CorrectionRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key for safely retrying the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
-                }
-            }
-        },
-        "/me": {
-            "get": {
-                "description": "Get profile of the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
                 ],
-                "summary": "Get current user profile",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.User"
+                            "$ref": "#/definitions/domain.AttendanceCorrection"
                         }
                     },
-                    "401": {
-                        "description": "unauthorized",
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "attendance not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "a correction is already pending",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -300,32 +381,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/organizations": {
-            "get": {
+        "/attendance/{attendance_id}/photo": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List organizations where the user is a member",
+                "description": "Upload a selfie or site photo for one of your own attendance records as the multipart field \"photo\". JPEG, PNG and WebP are accepted, as detected from the file content. A record takes a single photo.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Attendance"
+                ],
+                "summary": "Attach a photo to a check-in",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Attendance ID",
+                        "name": "attendance_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "List organizations",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Organization"
-                            }
+                            "$ref": "#/definitions/domain.Attendance"
+                        }
+                    },
+                    "400": {
+                        "description": "missing or empty photo",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "attendance not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "attendance already has a photo",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "photo too large",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported photo type",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
@@ -335,14 +459,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new organization and assign the creator as OWNER",
+                "description": "Login with email and password to get JWT token",
                 "consumes": [
                     "application/json"
                 ],
@@ -350,25 +471,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Auth"
                 ],
-                "summary": "Create a new organization",
+                "summary": "Login user",
                 "parameters": [
                     {
-                        "description": "Organization Request",
+                        "description": "Login Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Organization"
+                            "$ref": "#/definitions/domain.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Organization"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -377,8 +501,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "401": {
+                        "description": "unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -386,14 +510,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/organizations/{org_id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get details of a specific organization",
+        "/auth/refresh": {
+            "post": {
+                "description": "Get a new access token using a refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -401,46 +520,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Auth"
                 ],
-                "summary": "Get organization details",
+                "summary": "Refresh access token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "org_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh Token Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Organization"
+                            "$ref": "#/definitions/domain.TokenPair"
                         }
                     },
-                    "404": {
-                        "description": "organization not found",
+                    "400": {
+                        "description": "invalid request body or validation errors",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "401": {
+                        "description": "unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update details of an organization (Owner only)",
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user with the provided details",
                 "consumes": [
                     "application/json"
                 ],
@@ -448,39 +566,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Auth"
                 ],
-                "summary": "Update organization details",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "org_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Organization Request",
+                        "description": "Register Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Organization"
+                            "$ref": "#/definitions/domain.RegisterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "invalid request body or validation errors",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/domain.User"
                         }
                     },
-                    "403": {
-                        "description": "forbidden",
+                    "400": {
+                        "description": "invalid request body or validation errors",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -492,14 +600,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/kiosk/check-in": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "KioskAuth": []
                     }
                 ],
-                "description": "Delete an organization (Owner only)",
+                "description": "Check in the member identified by PIN or badge at the kiosk. The kiosk stands in for the member's location.",
                 "consumes": [
                     "application/json"
                 ],
@@ -507,30 +617,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Kiosk"
                 ],
-                "summary": "Delete organization",
+                "summary": "Kiosk check-in",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "org_id",
-                        "in": "path",
-                        "required": true
+                        "description": "PIN or badge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.KioskPunchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Attendance"
+                        }
                     },
-                    "403": {
-                        "description": "forbidden",
+                    "400": {
+                        "description": "invalid request body, validation errors or already checked in",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "401": {
+                        "description": "invalid kiosk token, or unknown pin or badge",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "a concurrent check-in opened a session first",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "too many failed attempts",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -538,14 +665,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/organizations/{org_id}/employees": {
-            "get": {
+        "/kiosk/check-out": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "KioskAuth": []
                     }
                 ],
-                "description": "Get list of employees in the organization (Owner/Manager only)",
+                "description": "Check out the member identified by PIN or badge from their open session in the kiosk's organization.",
                 "consumes": [
                     "application/json"
                 ],
@@ -553,36 +680,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Kiosk"
                 ],
-                "summary": "Get employees",
+                "summary": "Kiosk check-out",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "org_id",
-                        "in": "path",
-                        "required": true
+                        "description": "PIN or badge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.KioskPunchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.OrganizationMemberDetail"
-                            }
+                            "$ref": "#/definitions/domain.Attendance"
                         }
                     },
-                    "403": {
-                        "description": "forbidden",
+                    "400": {
+                        "description": "invalid request body, validation errors or not checked in",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "401": {
+                        "description": "invalid kiosk token, or unknown pin or badge",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "too many failed attempts",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -590,14 +722,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/organizations/{org_id}/employees/{user_id}": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update employee role or group (Owner/Manager only)",
+        "/logout": {
+            "post": {
+                "description": "Logout the user (client-side token removal, server-side placeholder)",
                 "consumes": [
                     "application/json"
                 ],
@@ -605,46 +732,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "User"
                 ],
-                "summary": "Update employee",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "org_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Logout user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateEmployeeRequest"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "description": "Get profile of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get current user profile",
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "invalid request body or validation errors",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/domain.User"
                         }
                     },
-                    "403": {
-                        "description": "forbidden",
+                    "401": {
+                        "description": "unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -656,14 +781,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/me/attendance": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove an employee from the organization (Owner/Manager only)",
+                "description": "List the authenticated user's attendance records, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -671,31 +798,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Attendance"
                 ],
-                "summary": "Remove employee",
+                "summary": "List my attendance",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Earliest check-in (YYYY-MM-DD or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest check-in (YYYY-MM-DD inclusive, or RFC 3339 exclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Organization ID",
-                        "name": "org_id",
-                        "in": "path",
-                        "required": true
+                        "name": "organization_id",
+                        "in": "query"
                     },
                     {
+                        "enum": [
+                            "GENERAL",
+                            "TASK"
+                        ],
                         "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Attendance type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PRESENT",
+                            "LATE",
+                            "ABSENT",
+                            "NON_WORKING_DAY"
+                        ],
+                        "type": "string",
+                        "description": "Attendance status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendancePage"
+                        }
                     },
-                    "403": {
-                        "description": "forbidden",
+                    "400": {
+                        "description": "invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -709,14 +877,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/organizations/{org_id}/groups": {
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List organizations where the user is a member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "List organizations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Organization"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new group/team for the organization",
+                "description": "Create a new organization and assign the creator as OWNER",
                 "consumes": [
                     "application/json"
                 ],
@@ -726,22 +929,15 @@ const docTemplate = `{
                 "tags": [
                     "Organization"
                 ],
-                "summary": "Create a group",
+                "summary": "Create a new organization",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "org_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Group Request",
+                        "description": "Organization Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Group"
+                            "$ref": "#/definitions/domain.Organization"
                         }
                     }
                 ],
@@ -749,7 +945,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Group"
+                            "$ref": "#/definitions/domain.Organization"
                         }
                     },
                     "400": {
@@ -767,14 +963,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/organizations/{org_id}/invitations": {
-            "post": {
+        "/organizations/{org_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Invite an employee to the organization",
+                "description": "Get details of a specific organization",
                 "consumes": [
                     "application/json"
                 ],
@@ -784,7 +980,7 @@ const docTemplate = `{
                 "tags": [
                     "Organization"
                 ],
-                "summary": "Invite an employee",
+                "summary": "Get organization details",
                 "parameters": [
                     {
                         "type": "string",
@@ -792,23 +988,17 @@ const docTemplate = `{
                         "name": "org_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Invite Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.InviteEmployeeRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Organization"
+                        }
                     },
-                    "400": {
-                        "description": "invalid request body or validation errors",
+                    "404": {
+                        "description": "organization not found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -820,16 +1010,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/organizations/{org_id}/members/{user_id}": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Assign a user to a specific group within the organization",
+                "description": "Update details of an organization (Owner only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -839,7 +1027,7 @@ const docTemplate = `{
                 "tags": [
                     "Organization"
                 ],
-                "summary": "Assign user to group",
+                "summary": "Update organization details",
                 "parameters": [
                     {
                         "type": "string",
@@ -849,19 +1037,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Assign Request",
+                        "description": "Organization Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.AssignUserToGroupRequest"
+                            "$ref": "#/definitions/domain.Organization"
                         }
                     }
                 ],
@@ -875,6 +1056,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "internal server error",
                         "schema": {
@@ -882,16 +1069,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/organizations/{org_id}/reports/groups/{group_id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get performance report for a specific group",
+                "description": "Delete an organization (Owner only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -899,9 +1084,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Organization"
                 ],
-                "summary": "Get group performance report",
+                "summary": "Delete organization",
                 "parameters": [
                     {
                         "type": "string",
@@ -909,20 +1094,16 @@ const docTemplate = `{
                         "name": "org_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "OK"
+                    },
+                    "403": {
+                        "description": "forbidden",
                         "schema": {
-                            "$ref": "#/definitions/domain.GroupPerformanceReport"
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
@@ -934,14 +1115,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/organizations/{org_id}/shifts": {
-            "post": {
+        "/organizations/{org_id}/attendance": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new shift for the organization",
+                "description": "List attendance records for the organization's members, newest first (Owner/Manager only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -949,9 +1130,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Attendance"
                 ],
-                "summary": "Create a shift",
+                "summary": "List team attendance",
                 "parameters": [
                     {
                         "type": "string",
@@ -961,24 +1142,97 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Shift Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Shift"
-                        }
+                        "type": "string",
+                        "description": "Only members of this group",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sessions checked in at this site",
+                        "name": "site_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this member",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest check-in (YYYY-MM-DD or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest check-in (YYYY-MM-DD inclusive, or RFC 3339 exclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "GENERAL",
+                            "TASK"
+                        ],
+                        "type": "string",
+                        "description": "Attendance type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PRESENT",
+                            "LATE",
+                            "ABSENT",
+                            "NON_WORKING_DAY"
+                        ],
+                        "type": "string",
+                        "description": "Attendance status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only open (true) or closed (false) sessions",
+                        "name": "open",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only records with at least this location risk score (1-100)",
+                        "name": "min_risk_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Shift"
+                            "$ref": "#/definitions/domain.TeamAttendancePage"
                         }
                     },
                     "400": {
-                        "description": "invalid request body or validation errors",
+                        "description": "invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -990,16 +1244,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/organizations/{org_id}/tasks": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new task for the organization",
+                "description": "Record a complete session on behalf of a member, e.g. after a dead phone battery (Owner/Manager only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1007,9 +1259,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task"
+                    "Attendance"
                 ],
-                "summary": "Create a task",
+                "summary": "Create a manual attendance record",
                 "parameters": [
                     {
                         "type": "string",
@@ -1019,20 +1271,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Task Request",
+                        "description": "Manual Attendance Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Task"
+                            "$ref": "#/definitions/domain.ManualAttendanceRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key for safely retrying the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Task"
+                            "$ref": "#/definitions/domain.Attendance"
                         }
                     },
                     "400": {
@@ -1041,6 +1299,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "task not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "internal server error",
                         "schema": {
@@ -1050,9 +1320,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/update-profile": {
+        "/organizations/{org_id}/attendance/{attendance_id}": {
             "put": {
-                "description": "Update profile of the authenticated user",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the check-in and check-out times of a member's attendance record. The record is marked MANUAL (Owner/Manager only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1060,25 +1335,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Attendance"
                 ],
-                "summary": "Update user profile",
+                "summary": "Edit an attendance record",
                 "parameters": [
                     {
-                        "description": "Update Request",
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attendance ID",
+                        "name": "attendance_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attendance Edit Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.RegisterRequest"
+                            "$ref": "#/definitions/domain.AttendanceEditRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key for safely retrying the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.User"
+                            "$ref": "#/definitions/domain.Attendance"
                         }
                     },
                     "400": {
@@ -1087,8 +1382,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "unauthorized",
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "attendance not found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -1101,45 +1402,2872 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.AssignUserToGroupRequest": {
-            "type": "object",
-            "required": [
-                "group_id"
-            ],
-            "properties": {
+        },
+        "/organizations/{org_id}/attendance/{attendance_id}/photo": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the photo attached to a member's attendance record (Owner/Manager only)",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Download a check-in photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attendance ID",
+                        "name": "attendance_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "attendance or photo not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/check-in-violations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the organization's latest check-ins refused by its geofence or network allowlist, newest first (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "List refused check-ins",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of violations (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.CheckInViolation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/checkpoints": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the organization's checkpoints (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkpoint"
+                ],
+                "summary": "List QR checkpoints",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Checkpoint"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a checkpoint whose screen shows a rotating QR code members scan to check in (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkpoint"
+                ],
+                "summary": "Create a QR checkpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Checkpoint Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Checkpoint"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Checkpoint"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/checkpoints/{checkpoint_id}/token": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the token the checkpoint's screen should display as a QR code, and when it rotates (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkpoint"
+                ],
+                "summary": "Get the current QR token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Checkpoint ID",
+                        "name": "checkpoint_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CheckpointToken"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "checkpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/corrections": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the organization's attendance correction requests, newest first (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Correction"
+                ],
+                "summary": "List correction requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "APPROVED",
+                            "REJECTED"
+                        ],
+                        "type": "string",
+                        "description": "Correction status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this member",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.AttendanceCorrection"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/corrections/{correction_id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply the requested times to the attendance record, keeping the original values on the correction (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Correction"
+                ],
+                "summary": "Approve a correction request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correction ID",
+                        "name": "correction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CorrectionReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendanceCorrection"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or the correction no longer applies",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "correction not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "correction already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/corrections/{correction_id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close a correction request without changing the attendance record (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Correction"
+                ],
+                "summary": "Reject a correction request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correction ID",
+                        "name": "correction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CorrectionReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendanceCorrection"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "correction not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "correction already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/employees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of employees in the organization (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Get employees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.OrganizationMemberDetail"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/employees/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update employee role or group (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Update employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateEmployeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an employee from the organization (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Remove employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/groups": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new group/team for the organization, working to a shift or a rotation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Create a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Group"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "rotation or site not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/invitations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invite an employee to the organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Invite an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invite Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.InviteEmployeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/kiosks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the organization's kiosks, including revoked ones (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "List kiosks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Kiosk"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a shared device that checks members in by PIN or badge (Owner/Manager only). The device token is returned only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Register a kiosk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kiosk Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Kiosk"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.KioskRegistration"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/kiosks/{kiosk_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop the kiosk's device token from working (Owner/Manager only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Revoke a kiosk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kiosk ID",
+                        "name": "kiosk_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "kiosk not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assign a user to a specific group within the organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Assign user to group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AssignUserToGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/members/{user_id}/kiosk-credentials": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the PIN and badge number a member uses at the organization's kiosks; an empty value removes it (Owner/Manager only). PINs and badges are unique within the organization.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Set a member's kiosk PIN and badge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kiosk Credentials Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.KioskCredentialsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "member not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "pin or badge id already exists",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/members/{user_id}/schedule-assignments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List a member's schedule assignments by effective date, past ones included (Owner/Manager, or the member themself)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "List a member's schedule assignments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ScheduleAssignment"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Put a member on a shift or a rotation from effective_from through effective_to, overriding their group's schedule on those dates (Owner/Manager only). An open-ended assignment in effect then is ended the day before; other overlaps are refused. Dates are in the shift's or rotation's timezone and cannot be in the past.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Assign a member a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule Assignment Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduleAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduleAssignment"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "shift or rotation not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "schedule assignment overlaps another",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/members/{user_id}/schedule-assignments/{assignment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a member's schedule assignment that has not yet taken effect (Owner/Manager only). Assignments already in effect are ended instead, keeping the history.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Delete a schedule assignment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule Assignment ID",
+                        "name": "assignment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "schedule assignment not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "schedule assignment already in effect",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/members/{user_id}/schedule-assignments/{assignment_id}/end": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the last day of a member's schedule assignment, after which they are back on their group's schedule (Owner/Manager only). It cannot end before today.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "End a schedule assignment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule Assignment ID",
+                        "name": "assignment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "End Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.EndScheduleAssignmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduleAssignment"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "schedule assignment not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "schedule assignment overlaps another",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/reports/groups/{group_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get performance report for a specific group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Get group performance report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First shift date (YYYY-MM-DD), defaults to 29 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last shift date (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GroupPerformanceReport"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid date range",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/rotations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the organization's rotations by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "List rotations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Rotation"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a repeating cycle of shifts and days off, such as 4-on/4-off (Owner/Manager only). Each entry of days is a shift ID, or null for a day off; the first falls on anchor_date and the cycle repeats from there. The shifts must share a timezone, which the rotation takes. Rotation names are unique within the organization.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Create a rotation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rotation Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Rotation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Rotation"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "shift not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "a rotation with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/rotations/{rotation_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one of the organization's rotations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get a rotation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rotation ID",
+                        "name": "rotation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Rotation"
+                        }
+                    },
+                    "404": {
+                        "description": "rotation not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/shifts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new shift for the organization. A split shift, such as 07:00-11:00 and 16:00-20:00, lists its segments in place of start_time and end_time; lateness and early leave are evaluated per segment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Create a shift",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Shift Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Shift"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Shift"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "site not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/sites": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the organization's work sites by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Site"
+                ],
+                "summary": "List sites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Site"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a work site, such as a branch, to the organization (Owner/Manager only). Site names are unique within the organization.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Site"
+                ],
+                "summary": "Create a site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Site Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Site"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "a site with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/sites/{site_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one of the organization's work sites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Site"
+                ],
+                "summary": "Get a site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Site ID",
+                        "name": "site_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Site"
+                        }
+                    },
+                    "404": {
+                        "description": "site not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a work site's details (Owner/Manager only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Site"
+                ],
+                "summary": "Update a site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Site ID",
+                        "name": "site_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Site Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Site"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "site not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "a site with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a work site (Owner/Manager only). Groups, shifts, tasks and attendance at the site are left without one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Site"
+                ],
+                "summary": "Delete a site",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Site ID",
+                        "name": "site_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "site not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/tasks": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new task for the organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Create a task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Task Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Task"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "site not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/update-profile": {
+            "put": {
+                "description": "Update profile of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AssignUserToGroupRequest": {
+            "type": "object",
+            "required": [
+                "group_id"
+            ],
+            "properties": {
                 "group_id": {
                     "type": "string"
                 }
             }
         },
+        "domain.Attendance": {
+            "type": "object",
+            "properties": {
+                "auto_closed": {
+                    "description": "Checked out by the system, not the member",
+                    "type": "boolean"
+                },
+                "check_in_time": {
+                    "type": "string"
+                },
+                "check_out_distance_meters": {
+                    "description": "Distance from the geofence center at check-out",
+                    "type": "number"
+                },
+                "check_out_kiosk_id": {
+                    "type": "string"
+                },
+                "check_out_lat": {
+                    "type": "number"
+                },
+                "check_out_long": {
+                    "type": "number"
+                },
+                "check_out_note": {
+                    "type": "string"
+                },
+                "check_out_offline": {
+                    "type": "boolean"
+                },
+                "check_out_status": {
+                    "description": "ON_TIME, EARLY_LEAVE",
+                    "type": "string"
+                },
+                "check_out_time": {
+                    "type": "string"
+                },
+                "checkpoint_id": {
+                    "description": "Checkpoint whose QR code was scanned",
+                    "type": "string"
+                },
+                "client_ip": {
+                    "description": "Address the check-in request came from, behind trusted proxies",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "description": "Distance from the geofence center, or outside its boundary, at check-in",
+                    "type": "number"
+                },
+                "early_leave_minutes": {
+                    "type": "integer"
+                },
+                "flag_reason": {
+                    "type": "string"
+                },
+                "flagged": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kiosk_id": {
+                    "description": "Kiosk the member checked in at",
+                    "type": "string"
+                },
+                "late_minutes": {
+                    "type": "integer"
+                },
+                "location_accuracy": {
+                    "description": "Meters, as reported by the device",
+                    "type": "number"
+                },
+                "location_lat": {
+                    "type": "number"
+                },
+                "location_long": {
+                    "type": "number"
+                },
+                "location_provider": {
+                    "type": "string"
+                },
+                "mock_location": {
+                    "description": "The device reported a mock location provider",
+                    "type": "boolean"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "offline": {
+                    "description": "Check-in captured on the device without connectivity and synced later",
+                    "type": "boolean"
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "overtime_minutes": {
+                    "type": "integer"
+                },
+                "photo_content_type": {
+                    "type": "string"
+                },
+                "photo_size_bytes": {
+                    "type": "integer"
+                },
+                "photo_uploaded_at": {
+                    "type": "string"
+                },
+                "proof_type": {
+                    "description": "GPS, QR, KIOSK; set for check-ins made on the device",
+                    "type": "string"
+                },
+                "recorded_by": {
+                    "description": "User who entered a MANUAL record",
+                    "type": "string"
+                },
+                "risk_reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "risk_score": {
+                    "description": "0-100; higher means the location is more likely spoofed",
+                    "type": "integer"
+                },
+                "scheduled_end": {
+                    "type": "string"
+                },
+                "scheduled_start": {
+                    "type": "string"
+                },
+                "shift_applied": {
+                    "type": "string"
+                },
+                "shift_date": {
+                    "description": "Working day of the shift instance, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "shift_segment": {
+                    "description": "1-based segment of a split shift the session is matched to",
+                    "type": "integer"
+                },
+                "site_id": {
+                    "description": "Site the member checked in at",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "DEVICE, MANUAL",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "PRESENT, LATE, ABSENT, NON_WORKING_DAY",
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "GENERAL, TASK",
+                    "type": "string"
+                },
+                "unpaid_break_minutes": {
+                    "description": "Sum of finished unpaid breaks, not stored",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "worked_minutes": {
+                    "description": "Computed for closed sessions, not stored",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.AttendanceBreak": {
+            "type": "object",
+            "properties": {
+                "attendance_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "boolean"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AttendanceCorrection": {
+            "type": "object",
+            "properties": {
+                "attendance_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "original_check_in_time": {
+                    "type": "string"
+                },
+                "original_check_out_time": {
+                    "type": "string"
+                },
+                "original_status": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requested_check_in_time": {
+                    "type": "string"
+                },
+                "requested_check_out_time": {
+                    "type": "string"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "PENDING, APPROVED, REJECTED",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AttendanceDetail": {
+            "type": "object",
+            "properties": {
+                "auto_closed": {
+                    "description": "Checked out by the system, not the member",
+                    "type": "boolean"
+                },
+                "check_in_time": {
+                    "type": "string"
+                },
+                "check_out_distance_meters": {
+                    "description": "Distance from the geofence center at check-out",
+                    "type": "number"
+                },
+                "check_out_kiosk_id": {
+                    "type": "string"
+                },
+                "check_out_lat": {
+                    "type": "number"
+                },
+                "check_out_long": {
+                    "type": "number"
+                },
+                "check_out_note": {
+                    "type": "string"
+                },
+                "check_out_offline": {
+                    "type": "boolean"
+                },
+                "check_out_status": {
+                    "description": "ON_TIME, EARLY_LEAVE",
+                    "type": "string"
+                },
+                "check_out_time": {
+                    "type": "string"
+                },
+                "checkpoint_id": {
+                    "description": "Checkpoint whose QR code was scanned",
+                    "type": "string"
+                },
+                "client_ip": {
+                    "description": "Address the check-in request came from, behind trusted proxies",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "description": "Distance from the geofence center, or outside its boundary, at check-in",
+                    "type": "number"
+                },
+                "early_leave_minutes": {
+                    "type": "integer"
+                },
+                "flag_reason": {
+                    "type": "string"
+                },
+                "flagged": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kiosk_id": {
+                    "description": "Kiosk the member checked in at",
+                    "type": "string"
+                },
+                "late_minutes": {
+                    "type": "integer"
+                },
+                "location_accuracy": {
+                    "description": "Meters, as reported by the device",
+                    "type": "number"
+                },
+                "location_lat": {
+                    "type": "number"
+                },
+                "location_long": {
+                    "type": "number"
+                },
+                "location_provider": {
+                    "type": "string"
+                },
+                "mock_location": {
+                    "description": "The device reported a mock location provider",
+                    "type": "boolean"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "offline": {
+                    "description": "Check-in captured on the device without connectivity and synced later",
+                    "type": "boolean"
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "overtime_minutes": {
+                    "type": "integer"
+                },
+                "photo_content_type": {
+                    "type": "string"
+                },
+                "photo_size_bytes": {
+                    "type": "integer"
+                },
+                "photo_uploaded_at": {
+                    "type": "string"
+                },
+                "proof_type": {
+                    "description": "GPS, QR, KIOSK; set for check-ins made on the device",
+                    "type": "string"
+                },
+                "recorded_by": {
+                    "description": "User who entered a MANUAL record",
+                    "type": "string"
+                },
+                "risk_reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "risk_score": {
+                    "description": "0-100; higher means the location is more likely spoofed",
+                    "type": "integer"
+                },
+                "scheduled_end": {
+                    "type": "string"
+                },
+                "scheduled_start": {
+                    "type": "string"
+                },
+                "shift_applied": {
+                    "type": "string"
+                },
+                "shift_date": {
+                    "description": "Working day of the shift instance, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "shift_segment": {
+                    "description": "1-based segment of a split shift the session is matched to",
+                    "type": "integer"
+                },
+                "site_id": {
+                    "description": "Site the member checked in at",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "DEVICE, MANUAL",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "PRESENT, LATE, ABSENT, NON_WORKING_DAY",
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "GENERAL, TASK",
+                    "type": "string"
+                },
+                "unpaid_break_minutes": {
+                    "description": "Sum of finished unpaid breaks, not stored",
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/domain.User"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "worked_minutes": {
+                    "description": "Computed for closed sessions, not stored",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.AttendanceEditRequest": {
+            "type": "object",
+            "required": [
+                "check_in_time",
+                "check_out_time",
+                "note"
+            ],
+            "properties": {
+                "check_in_time": {
+                    "type": "string"
+                },
+                "check_out_time": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "domain.AttendancePage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Attendance"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Boundary": {
+            "type": "object",
+            "required": [
+                "coordinates",
+                "type"
+            ],
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "Polygon",
+                        "MultiPolygon"
+                    ]
+                }
+            }
+        },
+        "domain.BreakType": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "paid": {
+                    "type": "boolean"
+                }
+            }
+        },
         "domain.CheckInRequest": {
             "type": "object",
             "required": [
-                "latitude",
-                "longitude",
                 "organization_id"
             ],
             "properties": {
+                "accuracy": {
+                    "description": "Horizontal accuracy in meters, as reported by the device",
+                    "type": "number",
+                    "minimum": 0
+                },
                 "latitude": {
                     "type": "number"
                 },
                 "longitude": {
                     "type": "number"
                 },
+                "mock_location": {
+                    "description": "The device reports the location came from a mock provider",
+                    "type": "boolean"
+                },
                 "note": {
                     "type": "string"
                 },
                 "organization_id": {
                     "type": "string"
                 },
+                "provider": {
+                    "description": "Location provider reported by the device, e.g. gps, network, fused",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "qr_token": {
+                    "description": "Token from a checkpoint's QR code; proves presence in place of coordinates",
+                    "type": "string",
+                    "maxLength": 200
+                },
                 "task_id": {
                     "type": "string"
                 }
             }
         },
+        "domain.CheckInViolation": {
+            "type": "object",
+            "properties": {
+                "client_ip": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "description": "Distance from the geofence center",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "GEOFENCE, NETWORK",
+                    "type": "string"
+                },
+                "location_lat": {
+                    "type": "number"
+                },
+                "location_long": {
+                    "type": "number"
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CheckOutRequest": {
+            "type": "object",
+            "properties": {
+                "attendance_id": {
+                    "description": "Close this session instead of the open one in the organization",
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "organization_id": {
+                    "description": "Needed only when checked in to several organizations",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Checkpoint": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "rotation_seconds": {
+                    "description": "Defaults to 30",
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 10
+                }
+            }
+        },
+        "domain.CheckpointToken": {
+            "type": "object",
+            "properties": {
+                "checkpoint_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "When the next token replaces this one",
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CorrectionRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "check_in_time": {
+                    "type": "string"
+                },
+                "check_out_time": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "domain.CorrectionReviewRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "domain.EndBreakRequest": {
+            "type": "object",
+            "properties": {
+                "organization_id": {
+                    "description": "Needed only when checked in to several organizations",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EndScheduleAssignmentRequest": {
+            "type": "object",
+            "required": [
+                "effective_to"
+            ],
+            "properties": {
+                "effective_to": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -1178,8 +4306,16 @@ const docTemplate = `{
                 "org_id": {
                     "type": "string"
                 },
+                "rotation_id": {
+                    "description": "In place of a shift",
+                    "type": "string"
+                },
                 "shift_id": {
                     "type": "string"
+                },
+                "site_id": {
+                    "description": "Where the group works; takes precedence over the shift's site",
+                    "type": "string"
                 }
             }
         },
@@ -1192,9 +4328,28 @@ const docTemplate = `{
                 "attendance_rate": {
                     "type": "string"
                 },
+                "attended_shifts": {
+                    "type": "integer"
+                },
+                "expected_shifts": {
+                    "description": "Shift instances in the period times group members",
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
                 "group_name": {
                     "type": "string"
                 },
+                "manual_entries": {
+                    "description": "Records in the period entered by an owner or manager",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
                 "total_late_checkins": {
                     "type": "integer"
                 }
@@ -1222,17 +4377,164 @@ const docTemplate = `{
                 }
             }
         },
-        "domain.LoginRequest": {
+        "domain.Kiosk": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "locked_until": {
+                    "description": "PIN and badge entry is refused until then",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.KioskCredentialsRequest": {
+            "type": "object",
+            "properties": {
+                "badge_id": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "pin": {
+                    "type": "string",
+                    "maxLength": 8,
+                    "minLength": 4
+                }
+            }
+        },
+        "domain.KioskPunchRequest": {
+            "type": "object",
+            "properties": {
+                "badge_id": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "pin": {
+                    "type": "string",
+                    "maxLength": 8,
+                    "minLength": 4
+                }
+            }
+        },
+        "domain.KioskRegistration": {
+            "type": "object",
+            "properties": {
+                "kiosk": {
+                    "$ref": "#/definitions/domain.Kiosk"
+                },
+                "token": {
+                    "description": "Device token; send it as \"Authorization: Kiosk \u003ctoken\u003e\"",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ManualAttendanceRequest": {
+            "type": "object",
+            "required": [
+                "check_in_time",
+                "check_out_time",
+                "note",
+                "user_id"
+            ],
+            "properties": {
+                "check_in_time": {
+                    "type": "string"
+                },
+                "check_out_time": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OfflineEvent": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "attendance_id": {
                     "type": "string"
                 },
-                "password": {
+                "captured_at": {
+                    "type": "string"
+                },
+                "client_event_id": {
+                    "type": "string"
+                },
+                "clock_offset_seconds": {
+                    "type": "integer"
+                },
+                "duplicate": {
+                    "description": "Synced before; this is the original result",
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event_time": {
+                    "description": "CapturedAt corrected by the clock offset",
+                    "type": "string"
+                },
+                "flag_reason": {
+                    "type": "string"
+                },
+                "flagged": {
+                    "description": "The resulting attendance record is flagged for review",
+                    "type": "boolean"
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "ACCEPTED, REJECTED",
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
@@ -1246,6 +4548,14 @@ const docTemplate = `{
                 "name"
             ],
             "properties": {
+                "allowed_networks": {
+                    "description": "CIDR ranges, e.g. 203.0.113.0/24",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -1258,11 +4568,41 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "geofence_mode": {
+                    "description": "OFF, WARN, REJECT",
+                    "type": "string",
+                    "enum": [
+                        "OFF",
+                        "WARN",
+                        "REJECT"
+                    ]
+                },
+                "geofence_radius_meters": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "id": {
                     "type": "string"
                 },
+                "location_rule": {
+                    "description": "ALL, ANY",
+                    "type": "string",
+                    "enum": [
+                        "ALL",
+                        "ANY"
+                    ]
+                },
                 "name": {
                     "type": "string"
+                },
+                "network_mode": {
+                    "description": "OFF, WARN, REJECT; applies to check-ins from outside AllowedNetworks",
+                    "type": "string",
+                    "enum": [
+                        "OFF",
+                        "WARN",
+                        "REJECT"
+                    ]
                 }
             }
         },
@@ -1329,18 +4669,102 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.Rotation": {
+            "type": "object",
+            "required": [
+                "anchor_date",
+                "days",
+                "name"
+            ],
+            "properties": {
+                "anchor_date": {
+                    "description": "YYYY-MM-DD in the rotation's timezone",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "days": {
+                    "description": "Shift ID for each day of the cycle; null is a day off",
+                    "type": "array",
+                    "maxItems": 366,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Shared by the rotation's shifts",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ScheduleAssignment": {
+            "type": "object",
+            "required": [
+                "effective_from"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "effective_to": {
+                    "description": "Last day, inclusive",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "rotation_id": {
+                    "description": "In place of a shift",
+                    "type": "string"
+                },
+                "shift_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.Shift": {
             "type": "object",
             "required": [
-                "end_time",
                 "name",
-                "start_time",
-                "timezone",
                 "working_days"
             ],
             "properties": {
+                "allowed_early_leave_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "allowed_late_minutes": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "break_types": {
+                    "description": "Breaks members may take; none means a single unpaid BREAK type",
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/domain.BreakType"
+                    }
                 },
                 "created_at": {
                     "type": "string"
@@ -1358,11 +4782,25 @@ const docTemplate = `{
                 "org_id": {
                     "type": "string"
                 },
+                "segments": {
+                    "description": "Stretches of a split shift, in order; late and early-leave tolerances apply to each",
+                    "type": "array",
+                    "maxItems": 4,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/domain.ShiftSegment"
+                    }
+                },
+                "site_id": {
+                    "description": "Where the shift is worked",
+                    "type": "string"
+                },
                 "start_time": {
-                    "description": "HH:MM",
+                    "description": "HH:MM; set from the segments of a split shift",
                     "type": "string"
                 },
                 "timezone": {
+                    "description": "IANA zone, e.g. Asia/Tbilisi; defaults to the site's",
                     "type": "string"
                 },
                 "working_days": {
@@ -1374,6 +4812,172 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.ShiftSegment": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "start_time"
+            ],
+            "properties": {
+                "end_time": {
+                    "description": "HH:MM",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "HH:MM",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Site": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude",
+                "name",
+                "timezone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "boundary": {
+                    "description": "Replaces the radius for irregular sites",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Boundary"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "radius_meters": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "timezone": {
+                    "description": "IANA zone; the default for shifts at the site",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.StartBreakRequest": {
+            "type": "object",
+            "properties": {
+                "organization_id": {
+                    "description": "Needed only when checked in to several organizations",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "One of the shift's break types; defaults to BREAK",
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "domain.SyncEvent": {
+            "type": "object",
+            "required": [
+                "captured_at",
+                "client_event_id",
+                "type"
+            ],
+            "properties": {
+                "accuracy": {
+                    "description": "Check-in location signals, as on CheckInRequest",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "captured_at": {
+                    "description": "Device clock",
+                    "type": "string"
+                },
+                "client_event_id": {
+                    "description": "Unique per user; retried events are not applied twice",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "clock_offset_seconds": {
+                    "description": "Device clock minus server clock, as last measured by the device",
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "mock_location": {
+                    "type": "boolean"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "CHECK_IN",
+                        "CHECK_OUT"
+                    ]
+                }
+            }
+        },
+        "domain.SyncRequest": {
+            "type": "object",
+            "required": [
+                "events"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.SyncEvent"
+                    }
+                }
+            }
+        },
+        "domain.SyncResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OfflineEvent"
+                    }
+                }
+            }
+        },
         "domain.Task": {
             "type": "object",
             "required": [
@@ -1383,6 +4987,9 @@ const docTemplate = `{
                 "assigned_user_id": {
                     "type": "string"
                 },
+                "boundary": {
+                    "$ref": "#/definitions/domain.Boundary"
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -1405,13 +5012,33 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "radius_meters": {
-                    "type": "integer"
+                    "description": "Required for geofencing without a boundary",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "site_id": {
+                    "description": "Site the task is done at",
+                    "type": "string"
                 },
                 "title": {
                     "type": "string"
                 }
             }
         },
+        "domain.TeamAttendancePage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AttendanceDetail"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.TokenPair": {
             "type": "object",
             "properties": {
@@ -1467,6 +5094,12 @@ const docTemplate = `{
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
+        },
+        "KioskAuth": {
+            "description": "Kiosk device token as \"Kiosk \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
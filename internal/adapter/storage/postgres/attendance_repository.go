@@ -3,13 +3,38 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/syst3mctl/check-in-api/internal/core/domain"
 	"github.com/syst3mctl/check-in-api/internal/core/port"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// attendanceColumns lists the attendance columns, aliased as "a", in the
+// order scanAttendance reads them.
+const attendanceColumns = `a.id, a.user_id, a.org_id, a.task_id, a.site_id, a.check_in_time, a.check_out_time, COALESCE(a.check_out_status, ''), a.early_leave_minutes, a.auto_closed, a.offline, a.check_out_offline, a.status, a.late_minutes, a.type, a.source, a.recorded_by, COALESCE(a.proof_type, ''), a.checkpoint_id, a.kiosk_id, a.check_out_kiosk_id, COALESCE(a.photo_key, ''), COALESCE(a.photo_content_type, ''), a.photo_size_bytes, a.photo_uploaded_at, a.location_accuracy, COALESCE(a.location_provider, ''), a.mock_location, a.risk_score, a.risk_reasons, COALESCE(a.client_ip, ''),
+		COALESCE(a.shift_applied, ''), COALESCE(a.shift_date::text, ''), a.shift_segment, a.scheduled_start, a.scheduled_end, a.overtime_minutes,
+		(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM b.end_time - b.start_time)), 0)::int / 60
+		 FROM attendance_breaks b WHERE b.attendance_id = a.id AND NOT b.paid AND b.end_time IS NOT NULL),
+		COALESCE(a.location_lat, 0), COALESCE(a.location_long, 0), a.distance_meters,
+		a.check_out_lat, a.check_out_long, a.check_out_distance_meters, a.flagged, COALESCE(a.flag_reason, ''), COALESCE(a.note, ''), COALESCE(a.check_out_note, ''), a.created_at`
+
+// scanAttendance scans attendanceColumns into att, followed by any extra
+// columns the query selects after them.
+func scanAttendance(row pgx.Row, att *domain.Attendance, extra ...any) error {
+	dest := []any{
+		&att.ID, &att.UserID, &att.OrgID, &att.TaskID, &att.SiteID, &att.CheckInTime, &att.CheckOutTime, &att.CheckOutStatus, &att.EarlyLeaveMinutes, &att.AutoClosed, &att.Offline, &att.CheckOutOffline, &att.Status, &att.LateMinutes, &att.Type, &att.Source, &att.RecordedBy, &att.ProofType, &att.CheckpointID, &att.KioskID, &att.CheckOutKioskID, &att.PhotoKey, &att.PhotoContentType, &att.PhotoSizeBytes, &att.PhotoUploadedAt, &att.LocationAccuracy, &att.LocationProvider, &att.MockLocation, &att.RiskScore, &att.RiskReasons, &att.ClientIP,
+		&att.ShiftApplied, &att.ShiftDate, &att.ShiftSegment, &att.ScheduledStart, &att.ScheduledEnd, &att.OvertimeMinutes, &att.UnpaidBreakMinutes,
+		&att.LocationLat, &att.LocationLong, &att.DistanceMeters,
+		&att.CheckOutLat, &att.CheckOutLong, &att.CheckOutDistanceMeters, &att.Flagged, &att.FlagReason, &att.Note, &att.CheckOutNote, &att.CreatedAt,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
 type AttendanceRepository struct {
 	db *DB
 }
@@ -20,20 +45,20 @@ func NewAttendanceRepository(db *DB) port.AttendanceRepository {
 
 func (r *AttendanceRepository) CreateTask(ctx context.Context, task *domain.Task) error {
 	query := `
-		INSERT INTO tasks (org_id, title, assigned_user_id, geofencing_enabled, location_name, latitude, longitude, radius_meters)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO tasks (org_id, title, assigned_user_id, geofencing_enabled, location_name, latitude, longitude, radius_meters, boundary, site_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id, created_at
 	`
 	executor := r.db.GetExecutor(ctx)
-	return executor.QueryRow(ctx, query, task.OrgID, task.Title, task.AssignedUserID, task.GeofencingEnabled, task.LocationName, task.Latitude, task.Longitude, task.RadiusMeters).
+	return executor.QueryRow(ctx, query, task.OrgID, task.Title, task.AssignedUserID, task.GeofencingEnabled, task.LocationName, task.Latitude, task.Longitude, task.RadiusMeters, task.Boundary, task.SiteID).
 		Scan(&task.ID, &task.CreatedAt)
 }
 
 func (r *AttendanceRepository) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
-	query := `SELECT id, org_id, title, assigned_user_id, geofencing_enabled, location_name, latitude, longitude, radius_meters, created_at FROM tasks WHERE id = $1`
+	query := `SELECT id, org_id, title, assigned_user_id, geofencing_enabled, location_name, latitude, longitude, radius_meters, boundary, site_id, created_at FROM tasks WHERE id = $1`
 	task := &domain.Task{}
 	executor := r.db.GetExecutor(ctx)
-	err := executor.QueryRow(ctx, query, id).Scan(&task.ID, &task.OrgID, &task.Title, &task.AssignedUserID, &task.GeofencingEnabled, &task.LocationName, &task.Latitude, &task.Longitude, &task.RadiusMeters, &task.CreatedAt)
+	err := executor.QueryRow(ctx, query, id).Scan(&task.ID, &task.OrgID, &task.Title, &task.AssignedUserID, &task.GeofencingEnabled, &task.LocationName, &task.Latitude, &task.Longitude, &task.RadiusMeters, &task.Boundary, &task.SiteID, &task.CreatedAt)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
@@ -43,39 +68,200 @@ func (r *AttendanceRepository) GetTaskByID(ctx context.Context, id string) (*dom
 	return task, nil
 }
 
+func (r *AttendanceRepository) CreateCheckpoint(ctx context.Context, checkpoint *domain.Checkpoint) error {
+	query := `
+		INSERT INTO checkpoints (org_id, name, rotation_seconds, secret)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	return executor.QueryRow(ctx, query, checkpoint.OrgID, checkpoint.Name, checkpoint.RotationSeconds, checkpoint.Secret).
+		Scan(&checkpoint.ID, &checkpoint.CreatedAt)
+}
+
+func (r *AttendanceRepository) GetCheckpointByID(ctx context.Context, id string) (*domain.Checkpoint, error) {
+	query := `SELECT id, org_id, name, rotation_seconds, secret, created_at FROM checkpoints WHERE id = $1`
+	c := &domain.Checkpoint{}
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, id).Scan(&c.ID, &c.OrgID, &c.Name, &c.RotationSeconds, &c.Secret, &c.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *AttendanceRepository) ListCheckpoints(ctx context.Context, orgID string) ([]*domain.Checkpoint, error) {
+	query := `SELECT id, org_id, name, rotation_seconds, created_at FROM checkpoints WHERE org_id = $1 ORDER BY name, id`
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checkpoints []*domain.Checkpoint
+	for rows.Next() {
+		c := &domain.Checkpoint{}
+		if err := rows.Scan(&c.ID, &c.OrgID, &c.Name, &c.RotationSeconds, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, c)
+	}
+	return checkpoints, rows.Err()
+}
+
+func (r *AttendanceRepository) UseCheckpointToken(ctx context.Context, checkpointID, userID string, window int64) error {
+	query := `INSERT INTO checkpoint_token_uses (checkpoint_id, time_window, user_id) VALUES ($1, $2, $3)`
+	executor := r.db.GetExecutor(ctx)
+	_, err := executor.Exec(ctx, query, checkpointID, window, userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "checkpoint_token_uses_pkey" {
+			return &domain.DuplicateError{Field: "checkpoint token use"}
+		}
+		return err
+	}
+	return nil
+}
+
 func (r *AttendanceRepository) CreateAttendance(ctx context.Context, attendance *domain.Attendance) error {
 	query := `
-		INSERT INTO attendance (user_id, org_id, task_id, check_in_time, status, type, shift_applied, location_lat, location_long, note)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO attendance (user_id, org_id, task_id, check_in_time, check_out_time, check_out_status, early_leave_minutes, status, late_minutes, type, source, recorded_by, shift_applied, shift_date, scheduled_start, scheduled_end, overtime_minutes, location_lat, location_long, distance_meters, flagged, flag_reason, note, offline, proof_type, checkpoint_id, kiosk_id, location_accuracy, location_provider, mock_location, risk_score, risk_reasons, client_ip, site_id, shift_segment)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10, $11, $12, $13, NULLIF($14, '')::date, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, NULLIF($25, ''), $26, $27, $28, NULLIF($29, ''), $30, $31, $32, NULLIF($33, ''), $34, $35)
 		RETURNING id, created_at
 	`
 	executor := r.db.GetExecutor(ctx)
-	return executor.QueryRow(ctx, query, attendance.UserID, attendance.OrgID, attendance.TaskID, attendance.CheckInTime, attendance.Status, attendance.Type, attendance.ShiftApplied, attendance.LocationLat, attendance.LocationLong, attendance.Note).
+	err := executor.QueryRow(ctx, query, attendance.UserID, attendance.OrgID, attendance.TaskID, attendance.CheckInTime, attendance.CheckOutTime, attendance.CheckOutStatus, attendance.EarlyLeaveMinutes, attendance.Status, attendance.LateMinutes, attendance.Type, attendance.Source, attendance.RecordedBy, attendance.ShiftApplied, attendance.ShiftDate, attendance.ScheduledStart, attendance.ScheduledEnd, attendance.OvertimeMinutes, attendance.LocationLat, attendance.LocationLong, attendance.DistanceMeters, attendance.Flagged, attendance.FlagReason, attendance.Note, attendance.Offline, attendance.ProofType, attendance.CheckpointID, attendance.KioskID, attendance.LocationAccuracy, attendance.LocationProvider, attendance.MockLocation, attendance.RiskScore, attendance.RiskReasons, attendance.ClientIP, attendance.SiteID, attendance.ShiftSegment).
 		Scan(&attendance.ID, &attendance.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_attendance_open_session" {
+			return &domain.DuplicateError{Field: "open session"}
+		}
+		return err
+	}
+	return nil
 }
 
 func (r *AttendanceRepository) UpdateAttendance(ctx context.Context, attendance *domain.Attendance) error {
 	query := `
 		UPDATE attendance
-		SET check_out_time = $2
+		SET check_in_time = $2, check_out_time = $3, status = $4, late_minutes = $5, overtime_minutes = $6,
+		    source = $7, recorded_by = $8, note = $9,
+		    check_out_status = NULLIF($10, ''), early_leave_minutes = $11, check_out_lat = $12, check_out_long = $13,
+		    check_out_distance_meters = $14, check_out_note = $15, flagged = $16, flag_reason = $17, check_out_offline = $18, check_out_kiosk_id = $19
 		WHERE id = $1
 	`
 	executor := r.db.GetExecutor(ctx)
-	_, err := executor.Exec(ctx, query, attendance.ID, attendance.CheckOutTime)
+	_, err := executor.Exec(ctx, query, attendance.ID, attendance.CheckInTime, attendance.CheckOutTime, attendance.Status, attendance.LateMinutes, attendance.OvertimeMinutes, attendance.Source, attendance.RecordedBy, attendance.Note,
+		attendance.CheckOutStatus, attendance.EarlyLeaveMinutes, attendance.CheckOutLat, attendance.CheckOutLong,
+		attendance.CheckOutDistanceMeters, attendance.CheckOutNote, attendance.Flagged, attendance.FlagReason, attendance.CheckOutOffline, attendance.CheckOutKioskID)
 	return err
 }
 
-func (r *AttendanceRepository) GetLatestAttendance(ctx context.Context, userID string) (*domain.Attendance, error) {
+func (r *AttendanceRepository) CreateCheckInViolation(ctx context.Context, violation *domain.CheckInViolation) error {
+	query := `
+		INSERT INTO check_in_violations (org_id, user_id, kind, client_ip, location_lat, location_long, distance_meters)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7)
+		RETURNING id, created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	return executor.QueryRow(ctx, query, violation.OrgID, violation.UserID, violation.Kind, violation.ClientIP, violation.LocationLat, violation.LocationLong, violation.DistanceMeters).
+		Scan(&violation.ID, &violation.CreatedAt)
+}
+
+func (r *AttendanceRepository) ListCheckInViolations(ctx context.Context, orgID string, limit int) ([]*domain.CheckInViolation, error) {
+	query := `
+		SELECT id, org_id, user_id, kind, COALESCE(client_ip, ''), location_lat, location_long, distance_meters, created_at
+		FROM check_in_violations
+		WHERE org_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, orgID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	violations := []*domain.CheckInViolation{}
+	for rows.Next() {
+		v := &domain.CheckInViolation{}
+		if err := rows.Scan(&v.ID, &v.OrgID, &v.UserID, &v.Kind, &v.ClientIP, &v.LocationLat, &v.LocationLong, &v.DistanceMeters, &v.CreatedAt); err != nil {
+			return nil, err
+		}
+		violations = append(violations, v)
+	}
+	return violations, rows.Err()
+}
+
+func (r *AttendanceRepository) SetAttendancePhoto(ctx context.Context, attendance *domain.Attendance) (bool, error) {
 	query := `
-		SELECT id, user_id, org_id, task_id, check_in_time, check_out_time, status, type, shift_applied, location_lat, location_long, note, created_at
-		FROM attendance
-		WHERE user_id = $1
-		ORDER BY created_at DESC
+		UPDATE attendance
+		SET photo_key = $2, photo_content_type = $3, photo_size_bytes = $4, photo_uploaded_at = $5
+		WHERE id = $1 AND photo_key IS NULL
+	`
+	executor := r.db.GetExecutor(ctx)
+	tag, err := executor.Exec(ctx, query, attendance.ID, attendance.PhotoKey, attendance.PhotoContentType, attendance.PhotoSizeBytes, attendance.PhotoUploadedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *AttendanceRepository) GetLastPunchLocation(ctx context.Context, userID string, before time.Time) (*domain.PunchLocation, error) {
+	// Check-ins without coordinates, such as QR, kiosk and manual ones, are stored at 0,0
+	query := `
+		SELECT t, lat, long FROM (
+			SELECT check_in_time AS t, location_lat AS lat, location_long AS long
+			FROM attendance
+			WHERE user_id = $1 AND check_in_time < $2 AND NOT (location_lat = 0 AND location_long = 0)
+			UNION ALL
+			SELECT check_out_time, check_out_lat, check_out_long
+			FROM attendance
+			WHERE user_id = $1 AND check_out_time < $2 AND check_out_lat IS NOT NULL AND check_out_long IS NOT NULL
+		) punches
+		ORDER BY t DESC
 		LIMIT 1
 	`
+	var p domain.PunchLocation
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, userID, before).Scan(&p.Time, &p.Latitude, &p.Longitude)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *AttendanceRepository) CoordinatesUsedByOthers(ctx context.Context, userID string, lat, long float64, since time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM attendance
+			WHERE location_lat = $2 AND location_long = $3 AND user_id <> $1 AND check_in_time >= $4
+		)
+	`
+	var used bool
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, userID, lat, long, since).Scan(&used)
+	return used, err
+}
+
+func (r *AttendanceRepository) GetAttendanceByID(ctx context.Context, id string) (*domain.Attendance, error) {
+	query := `
+		SELECT ` + attendanceColumns + `
+		FROM attendance a
+		WHERE a.id = $1
+	`
 	att := &domain.Attendance{}
 	executor := r.db.GetExecutor(ctx)
-	err := executor.QueryRow(ctx, query, userID).Scan(&att.ID, &att.UserID, &att.OrgID, &att.TaskID, &att.CheckInTime, &att.CheckOutTime, &att.Status, &att.Type, &att.ShiftApplied, &att.LocationLat, &att.LocationLong, &att.Note, &att.CreatedAt)
+	err := scanAttendance(executor.QueryRow(ctx, query, id), att)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
@@ -85,26 +271,181 @@ func (r *AttendanceRepository) GetLatestAttendance(ctx context.Context, userID s
 	return att, nil
 }
 
-func (r *AttendanceRepository) GetMemberGroup(ctx context.Context, orgID, userID string) (*domain.Group, *domain.Shift, error) {
-	// Join organization_members -> groups -> shifts
+func (r *AttendanceRepository) ListOpenAttendance(ctx context.Context, userID, orgID string) ([]*domain.Attendance, error) {
+	query := `
+		SELECT ` + attendanceColumns + `
+		FROM attendance a
+		WHERE a.user_id = $1 AND a.check_out_time IS NULL AND a.status <> 'ABSENT'
+	`
+	args := []any{userID}
+	if orgID != "" {
+		query += " AND a.org_id = $2"
+		args = append(args, orgID)
+	}
+	query += " ORDER BY a.check_in_time DESC"
+
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Attendance
+	for rows.Next() {
+		att := &domain.Attendance{}
+		if err := scanAttendance(rows, att); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, att)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *AttendanceRepository) ListAttendance(ctx context.Context, filter domain.AttendanceFilter) ([]*domain.Attendance, error) {
+	where, args := attendanceFilterClause(filter)
+	args = append(args, filter.Limit)
+	query := `
+		SELECT ` + attendanceColumns + `
+		FROM attendance a
+		WHERE ` + where + `
+		ORDER BY a.check_in_time DESC, a.id DESC
+		LIMIT $` + fmt.Sprint(len(args))
+
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*domain.Attendance
+	for rows.Next() {
+		att := &domain.Attendance{}
+		if err := scanAttendance(rows, att); err != nil {
+			return nil, err
+		}
+		records = append(records, att)
+	}
+	return records, rows.Err()
+}
+
+// attendanceFilterClause builds the WHERE conditions and arguments for an
+// attendance filter against the table aliased as "a".
+func attendanceFilterClause(filter domain.AttendanceFilter) (string, []any) {
+	var conds []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.UserID != "" {
+		add("a.user_id = $%d", filter.UserID)
+	}
+	if filter.OrgID != "" {
+		add("a.org_id = $%d", filter.OrgID)
+	}
+	if filter.GroupID != "" {
+		add(`EXISTS (
+			SELECT 1 FROM organization_members om
+			WHERE om.org_id = a.org_id AND om.user_id = a.user_id AND om.group_id = $%d
+		)`, filter.GroupID)
+	}
+	if filter.SiteID != "" {
+		add("a.site_id = $%d", filter.SiteID)
+	}
+	if filter.From != nil {
+		add("a.check_in_time >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("a.check_in_time < $%d", *filter.To)
+	}
+	if filter.Type != "" {
+		add("a.type = $%d", filter.Type)
+	}
+	if filter.Status != "" {
+		add("a.status = $%d", filter.Status)
+	}
+	if filter.MinRiskScore > 0 {
+		add("a.risk_score >= $%d", filter.MinRiskScore)
+	}
+	if filter.Open != nil {
+		if *filter.Open {
+			conds = append(conds, "a.check_out_time IS NULL AND a.status <> 'ABSENT'")
+		} else {
+			conds = append(conds, "a.check_out_time IS NOT NULL")
+		}
+	}
+	if filter.CursorTime != nil {
+		args = append(args, *filter.CursorTime, filter.CursorID)
+		conds = append(conds, fmt.Sprintf("(a.check_in_time, a.id) < ($%d, $%d::uuid)", len(args)-1, len(args)))
+	}
+
+	if len(conds) == 0 {
+		return "TRUE", args
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+func (r *AttendanceRepository) ListOrgAttendance(ctx context.Context, filter domain.AttendanceFilter) ([]*domain.AttendanceDetail, error) {
+	where, args := attendanceFilterClause(filter)
+	args = append(args, filter.Limit)
+	query := `
+		SELECT ` + attendanceColumns + `,
+			u.id, u.full_name, u.email, u.phone_number, u.created_at
+		FROM attendance a
+		JOIN users u ON a.user_id = u.id
+		WHERE ` + where + `
+		ORDER BY a.check_in_time DESC, a.id DESC
+		LIMIT $` + fmt.Sprint(len(args))
+
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*domain.AttendanceDetail
+	for rows.Next() {
+		var d domain.AttendanceDetail
+		if err := scanAttendance(rows, &d.Attendance, &d.User.ID, &d.User.FullName, &d.User.Email, &d.User.PhoneNumber, &d.User.CreatedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, &d)
+	}
+	return records, rows.Err()
+}
+
+func (r *AttendanceRepository) GetMemberGroup(ctx context.Context, orgID, userID string, at time.Time) (*domain.Group, *domain.Schedule, error) {
+	// Join organization_members -> groups, and the schedule assignment
+	// covering the date in its shift's or rotation's own timezone
 	query := `
-		SELECT g.id, g.name, g.shift_id, s.id, s.name, s.start_time, s.end_time, s.timezone, s.allowed_late_minutes, s.working_days
+		SELECT g.id, g.name, g.shift_id, g.rotation_id, g.site_id, assigned.shift_id, assigned.rotation_id
 		FROM organization_members om
-		JOIN groups g ON om.group_id = g.id
-		LEFT JOIN shifts s ON g.shift_id = s.id
+		LEFT JOIN groups g ON om.group_id = g.id
+		LEFT JOIN LATERAL (
+			SELECT sa.shift_id, sa.rotation_id
+			FROM schedule_assignments sa
+			LEFT JOIN shifts ss ON sa.shift_id = ss.id
+			LEFT JOIN rotations rr ON sa.rotation_id = rr.id
+			WHERE sa.org_id = om.org_id AND sa.user_id = om.user_id
+			  AND sa.effective_from <= ($3::timestamptz AT TIME ZONE COALESCE(ss.timezone, rr.timezone))::date
+			  AND (sa.effective_to IS NULL OR sa.effective_to >= ($3::timestamptz AT TIME ZONE COALESCE(ss.timezone, rr.timezone))::date)
+			ORDER BY sa.effective_from DESC
+			LIMIT 1
+		) assigned ON true
 		WHERE om.org_id = $1 AND om.user_id = $2
 	`
 	group := &domain.Group{}
-	shift := &domain.Shift{}
 
 	executor := r.db.GetExecutor(ctx)
-	var shiftID, shiftName, shiftStartTime, shiftEndTime, shiftTimezone *string
-	var shiftLate *int
-	var shiftDays []string
+	var groupID, groupName *string
+	var assignedShiftID, assignedRotationID *string
 
-	err := executor.QueryRow(ctx, query, orgID, userID).Scan(
-		&group.ID, &group.Name, &group.ShiftID,
-		&shiftID, &shiftName, &shiftStartTime, &shiftEndTime, &shiftTimezone, &shiftLate, &shiftDays,
+	err := executor.QueryRow(ctx, query, orgID, userID, at).Scan(
+		&groupID, &groupName, &group.ShiftID, &group.RotationID, &group.SiteID, &assignedShiftID, &assignedRotationID,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil, nil
@@ -113,16 +454,197 @@ func (r *AttendanceRepository) GetMemberGroup(ctx context.Context, orgID, userID
 		return nil, nil, err
 	}
 
-	if shiftID != nil {
-		shift.ID = *shiftID
-		shift.Name = *shiftName
-		shift.StartTime = *shiftStartTime
-		shift.EndTime = *shiftEndTime
-		shift.Timezone = *shiftTimezone
-		shift.AllowedLateMinutes = *shiftLate
-		shift.WorkingDays = shiftDays
-		return group, shift, nil
+	shiftID, rotationID := group.ShiftID, group.RotationID
+	if assignedShiftID != nil || assignedRotationID != nil {
+		shiftID, rotationID = assignedShiftID, assignedRotationID
+	}
+	if groupID != nil {
+		group.ID = *groupID
+		group.Name = *groupName
+	} else {
+		group = nil // Scheduled through assignments only
+	}
+
+	schedule, err := loadSchedule(ctx, executor, shiftID, rotationID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, schedule, nil
+}
+
+func (r *AttendanceRepository) ListScheduledMembers(ctx context.Context, since time.Time) ([]*domain.ScheduledMember, error) {
+	query := `
+		SELECT om.org_id, om.user_id, om.created_at, g.shift_id, g.rotation_id, '', NULL
+		FROM organization_members om
+		JOIN groups g ON om.group_id = g.id
+		WHERE g.shift_id IS NOT NULL OR g.rotation_id IS NOT NULL
+		UNION ALL
+		SELECT om.org_id, om.user_id, om.created_at, sa.shift_id, sa.rotation_id, sa.effective_from::text, sa.effective_to::text
+		FROM schedule_assignments sa
+		JOIN organization_members om ON om.org_id = sa.org_id AND om.user_id = sa.user_id
+		LEFT JOIN shifts s ON sa.shift_id = s.id
+		LEFT JOIN rotations rr ON sa.rotation_id = rr.id
+		WHERE sa.effective_to IS NULL OR sa.effective_to >= ($1::timestamptz AT TIME ZONE COALESCE(s.timezone, rr.timezone))::date
+	`
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type scheduled struct {
+		member              *domain.ScheduledMember
+		shiftID, rotationID *string
+	}
+	var list []scheduled
+	for rows.Next() {
+		var m domain.ScheduledMember
+		var shiftID, rotationID *string
+		if err := rows.Scan(&m.OrgID, &m.UserID, &m.JoinedAt, &shiftID, &rotationID, &m.EffectiveFrom, &m.EffectiveTo); err != nil {
+			return nil, err
+		}
+		list = append(list, scheduled{&m, shiftID, rotationID})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	rows.Close() // Free the connection for loading the schedules
+
+	members := make([]*domain.ScheduledMember, 0, len(list))
+	schedules := make(map[string]*domain.Schedule)
+	for _, item := range list {
+		schedule, err := loadSchedule(ctx, executor, item.shiftID, item.rotationID, schedules)
+		if err != nil {
+			return nil, err
+		}
+		item.member.Schedule = *schedule
+		members = append(members, item.member)
+	}
+	return members, nil
+}
 
-	return group, nil, nil
+func (r *AttendanceRepository) CreateAbsence(ctx context.Context, attendance *domain.Attendance) (bool, error) {
+	// A member counts as present for the instance if any record is attributed
+	// to its date and segment or started while it was running. The partial
+	// unique index on ABSENT rows keeps concurrent runs from inserting duplicates.
+	query := `
+		INSERT INTO attendance (user_id, org_id, check_in_time, status, type, shift_applied, shift_date, shift_segment, scheduled_start, scheduled_end, note)
+		SELECT $1::uuid, $2::uuid, $3::timestamptz, $4::text, $5::text, $6::text, $7::date, $10::int, $3::timestamptz, $8::timestamptz, $9::text
+		WHERE NOT EXISTS (
+			SELECT 1 FROM attendance
+			WHERE user_id = $1::uuid AND org_id = $2::uuid
+			  AND ((shift_date = $7::date AND shift_segment = $10::int) OR (check_in_time >= $3::timestamptz AND check_in_time < $8::timestamptz))
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id, created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, attendance.UserID, attendance.OrgID, attendance.CheckInTime, attendance.Status, attendance.Type, attendance.ShiftApplied, attendance.ShiftDate, attendance.ScheduledEnd, attendance.Note, attendance.ShiftSegment).
+		Scan(&attendance.ID, &attendance.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *AttendanceRepository) ListStaleSessions(ctx context.Context, shiftEndedBefore, checkedInBefore time.Time) ([]*domain.Attendance, error) {
+	query := `
+		SELECT ` + attendanceColumns + `
+		FROM attendance a
+		WHERE a.check_out_time IS NULL AND a.status <> 'ABSENT'
+		  AND ((a.scheduled_end IS NOT NULL AND a.scheduled_end <= $1)
+		    OR (a.scheduled_end IS NULL AND a.check_in_time <= $2))
+	`
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, shiftEndedBefore, checkedInBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Attendance
+	for rows.Next() {
+		att := &domain.Attendance{}
+		if err := scanAttendance(rows, att); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, att)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *AttendanceRepository) CloseAttendance(ctx context.Context, attendance *domain.Attendance) (bool, error) {
+	query := `
+		UPDATE attendance
+		SET check_out_time = $2, overtime_minutes = $3, auto_closed = $4
+		WHERE id = $1 AND check_out_time IS NULL
+	`
+	executor := r.db.GetExecutor(ctx)
+	tag, err := executor.Exec(ctx, query, attendance.ID, attendance.CheckOutTime, attendance.OvertimeMinutes, attendance.AutoClosed)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *AttendanceRepository) GetOpenBreak(ctx context.Context, attendanceID string) (*domain.AttendanceBreak, error) {
+	query := `
+		SELECT id, attendance_id, break_type, paid, start_time, end_time, created_at
+		FROM attendance_breaks
+		WHERE attendance_id = $1 AND end_time IS NULL
+	`
+	brk := &domain.AttendanceBreak{}
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, attendanceID).Scan(&brk.ID, &brk.AttendanceID, &brk.Type, &brk.Paid, &brk.StartTime, &brk.EndTime, &brk.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return brk, nil
+}
+
+func (r *AttendanceRepository) CreateBreak(ctx context.Context, brk *domain.AttendanceBreak) error {
+	query := `
+		INSERT INTO attendance_breaks (attendance_id, break_type, paid, start_time)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, brk.AttendanceID, brk.Type, brk.Paid, brk.StartTime).
+		Scan(&brk.ID, &brk.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_attendance_breaks_open" {
+			return &domain.DuplicateError{Field: "open break"}
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *AttendanceRepository) EndBreak(ctx context.Context, attendanceID string, at time.Time) (*domain.AttendanceBreak, error) {
+	// A break that started after the given time (e.g. when a session is
+	// auto-closed at its shift end) ends at its start rather than before it.
+	query := `
+		UPDATE attendance_breaks
+		SET end_time = GREATEST(start_time, $2)
+		WHERE attendance_id = $1 AND end_time IS NULL
+		RETURNING id, attendance_id, break_type, paid, start_time, end_time, created_at
+	`
+	brk := &domain.AttendanceBreak{}
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, attendanceID, at).Scan(&brk.ID, &brk.AttendanceID, &brk.Type, &brk.Paid, &brk.StartTime, &brk.EndTime, &brk.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return brk, nil
 }
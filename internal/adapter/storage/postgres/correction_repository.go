@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const correctionColumns = `id, attendance_id, user_id, org_id, requested_check_in_time, requested_check_out_time,
+		original_check_in_time, original_check_out_time, original_status, reason, status,
+		reviewed_by, reviewed_at, COALESCE(review_note, ''), created_at`
+
+func scanCorrection(row pgx.Row, c *domain.AttendanceCorrection) error {
+	return row.Scan(
+		&c.ID, &c.AttendanceID, &c.UserID, &c.OrgID, &c.RequestedCheckInTime, &c.RequestedCheckOutTime,
+		&c.OriginalCheckInTime, &c.OriginalCheckOutTime, &c.OriginalStatus, &c.Reason, &c.Status,
+		&c.ReviewedBy, &c.ReviewedAt, &c.ReviewNote, &c.CreatedAt,
+	)
+}
+
+type CorrectionRepository struct {
+	db *DB
+}
+
+func NewCorrectionRepository(db *DB) port.CorrectionRepository {
+	return &CorrectionRepository{db: db}
+}
+
+func (r *CorrectionRepository) CreateCorrection(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	query := `
+		INSERT INTO attendance_corrections (attendance_id, user_id, org_id, requested_check_in_time, requested_check_out_time, original_check_in_time, original_check_out_time, original_status, reason, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, correction.AttendanceID, correction.UserID, correction.OrgID, correction.RequestedCheckInTime, correction.RequestedCheckOutTime, correction.OriginalCheckInTime, correction.OriginalCheckOutTime, correction.OriginalStatus, correction.Reason, correction.Status).
+		Scan(&correction.ID, &correction.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_attendance_corrections_pending" {
+			return &domain.DuplicateError{Field: "pending correction"}
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *CorrectionRepository) GetCorrectionByID(ctx context.Context, id string) (*domain.AttendanceCorrection, error) {
+	query := `SELECT ` + correctionColumns + ` FROM attendance_corrections WHERE id = $1`
+	c := &domain.AttendanceCorrection{}
+	executor := r.db.GetExecutor(ctx)
+	err := scanCorrection(executor.QueryRow(ctx, query, id), c)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *CorrectionRepository) ListCorrections(ctx context.Context, filter domain.CorrectionFilter) ([]*domain.AttendanceCorrection, error) {
+	conds := []string{"TRUE"}
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.OrgID != "" {
+		add("org_id = $%d", filter.OrgID)
+	}
+	if filter.UserID != "" {
+		add("user_id = $%d", filter.UserID)
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+
+	query := `
+		SELECT ` + correctionColumns + `
+		FROM attendance_corrections
+		WHERE ` + strings.Join(conds, " AND ") + `
+		ORDER BY created_at DESC, id DESC
+	`
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var corrections []*domain.AttendanceCorrection
+	for rows.Next() {
+		c := &domain.AttendanceCorrection{}
+		if err := scanCorrection(rows, c); err != nil {
+			return nil, err
+		}
+		corrections = append(corrections, c)
+	}
+	return corrections, rows.Err()
+}
+
+func (r *CorrectionRepository) ReviewCorrection(ctx context.Context, correction *domain.AttendanceCorrection) (bool, error) {
+	query := `
+		UPDATE attendance_corrections
+		SET status = $2, reviewed_by = $3, reviewed_at = $4, review_note = $5,
+		    original_check_in_time = $6, original_check_out_time = $7, original_status = $8
+		WHERE id = $1 AND status = 'PENDING'
+	`
+	executor := r.db.GetExecutor(ctx)
+	tag, err := executor.Exec(ctx, query, correction.ID, correction.Status, correction.ReviewedBy, correction.ReviewedAt, correction.ReviewNote, correction.OriginalCheckInTime, correction.OriginalCheckOutTime, correction.OriginalStatus)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type IdempotencyRepository struct {
+	db *DB
+}
+
+func NewIdempotencyRepository(db *DB) port.IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (r *IdempotencyRepository) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, staleBefore time.Time) error {
+	// The upsert only overwrites an existing row that has expired or was
+	// abandoned mid-request, so a live key yields no row.
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, response_status = NULL, response_body = NULL,
+		    created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+		   OR (idempotency_keys.response_status IS NULL AND idempotency_keys.created_at <= $6)
+		RETURNING created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, key.UserID, key.Key, key.RequestHash, key.CreatedAt, key.ExpiresAt, staleBefore).
+		Scan(&key.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &domain.DuplicateError{Field: "idempotency key"}
+	}
+	return err
+}
+
+func (r *IdempotencyRepository) GetIdempotencyKey(ctx context.Context, userID, key string) (*domain.IdempotencyKey, error) {
+	query := `
+		SELECT user_id, key, request_hash, response_status, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`
+	k := &domain.IdempotencyKey{}
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, userID, key).
+		Scan(&k.UserID, &k.Key, &k.RequestHash, &k.ResponseStatus, &k.ResponseBody, &k.CreatedAt, &k.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (r *IdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error {
+	query := `
+		UPDATE idempotency_keys
+		SET response_status = $3, response_body = $4
+		WHERE user_id = $1 AND key = $2 AND request_hash = $5
+	`
+	executor := r.db.GetExecutor(ctx)
+	_, err := executor.Exec(ctx, query, key.UserID, key.Key, key.ResponseStatus, key.ResponseBody, key.RequestHash)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, userID, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2 AND response_status IS NULL`
+	executor := r.db.GetExecutor(ctx)
+	_, err := executor.Exec(ctx, query, userID, key)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+	executor := r.db.GetExecutor(ctx)
+	tag, err := executor.Exec(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type KioskRepository struct {
+	db *DB
+}
+
+func NewKioskRepository(db *DB) port.KioskRepository {
+	return &KioskRepository{db: db}
+}
+
+const kioskColumns = `id, org_id, name, token_hash, locked_until, revoked_at, created_at`
+
+func scanKiosk(row pgx.Row, k *domain.Kiosk) error {
+	return row.Scan(&k.ID, &k.OrgID, &k.Name, &k.TokenHash, &k.LockedUntil, &k.RevokedAt, &k.CreatedAt)
+}
+
+func (r *KioskRepository) CreateKiosk(ctx context.Context, kiosk *domain.Kiosk) error {
+	query := `
+		INSERT INTO kiosks (org_id, name, token_hash)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	return executor.QueryRow(ctx, query, kiosk.OrgID, kiosk.Name, kiosk.TokenHash).Scan(&kiosk.ID, &kiosk.CreatedAt)
+}
+
+func (r *KioskRepository) GetKioskByID(ctx context.Context, id string) (*domain.Kiosk, error) {
+	return r.getKiosk(ctx, `SELECT `+kioskColumns+` FROM kiosks WHERE id = $1`, id)
+}
+
+func (r *KioskRepository) GetKioskByTokenHash(ctx context.Context, tokenHash string) (*domain.Kiosk, error) {
+	return r.getKiosk(ctx, `SELECT `+kioskColumns+` FROM kiosks WHERE token_hash = $1`, tokenHash)
+}
+
+func (r *KioskRepository) getKiosk(ctx context.Context, query string, arg string) (*domain.Kiosk, error) {
+	k := &domain.Kiosk{}
+	executor := r.db.GetExecutor(ctx)
+	err := scanKiosk(executor.QueryRow(ctx, query, arg), k)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (r *KioskRepository) ListKiosks(ctx context.Context, orgID string) ([]*domain.Kiosk, error) {
+	query := `
+		SELECT ` + kioskColumns + `
+		FROM kiosks
+		WHERE org_id = $1
+		ORDER BY created_at, id
+	`
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	kiosks := []*domain.Kiosk{}
+	for rows.Next() {
+		k := &domain.Kiosk{}
+		if err := scanKiosk(rows, k); err != nil {
+			return nil, err
+		}
+		kiosks = append(kiosks, k)
+	}
+	return kiosks, rows.Err()
+}
+
+func (r *KioskRepository) RevokeKiosk(ctx context.Context, id string, at time.Time) error {
+	query := `UPDATE kiosks SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+	executor := r.db.GetExecutor(ctx)
+	_, err := executor.Exec(ctx, query, id, at)
+	return err
+}
+
+func (r *KioskRepository) RecordFailedAttempt(ctx context.Context, id, source string, at, since time.Time) (int, error) {
+	// The new row is not visible to the count in the same statement, so it
+	// is added on. Attempts older than the window are no longer needed.
+	query := `
+		WITH attempt AS (
+			INSERT INTO kiosk_failed_attempts (kiosk_id, source, attempted_at) VALUES ($1, $2, $3)
+		), expired AS (
+			DELETE FROM kiosk_failed_attempts WHERE kiosk_id = $1 AND attempted_at <= $4
+		)
+		SELECT COUNT(a.id) + 1
+		FROM kiosks k
+		LEFT JOIN kiosk_failed_attempts a ON a.kiosk_id = k.id AND a.source = $2
+			AND a.attempted_at > $4 AND (k.locked_until IS NULL OR a.attempted_at >= k.locked_until)
+		WHERE k.id = $1
+	`
+	var count int
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, id, source, at, since).Scan(&count)
+	return count, err
+}
+
+func (r *KioskRepository) LockKiosk(ctx context.Context, id string, lockedUntil time.Time) error {
+	query := `UPDATE kiosks SET locked_until = $2 WHERE id = $1`
+	executor := r.db.GetExecutor(ctx)
+	_, err := executor.Exec(ctx, query, id, lockedUntil)
+	return err
+}
+
+func (r *KioskRepository) SetMemberCredentials(ctx context.Context, orgID, userID string, pinHash, badgeID *string) (bool, error) {
+	query := `
+		UPDATE organization_members
+		SET kiosk_pin_hash = $3, badge_id = $4
+		WHERE org_id = $1 AND user_id = $2
+	`
+	executor := r.db.GetExecutor(ctx)
+	tag, err := executor.Exec(ctx, query, orgID, userID, pinHash, badgeID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			switch pgErr.ConstraintName {
+			case "idx_organization_members_kiosk_pin":
+				return false, &domain.DuplicateError{Field: "pin"}
+			case "idx_organization_members_badge":
+				return false, &domain.DuplicateError{Field: "badge id"}
+			}
+		}
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *KioskRepository) FindMemberByPIN(ctx context.Context, orgID, pinHash string) (string, error) {
+	return r.findMember(ctx, `SELECT user_id FROM organization_members WHERE org_id = $1 AND kiosk_pin_hash = $2`, orgID, pinHash)
+}
+
+func (r *KioskRepository) FindMemberByBadge(ctx context.Context, orgID, badgeID string) (string, error) {
+	return r.findMember(ctx, `SELECT user_id FROM organization_members WHERE org_id = $1 AND badge_id = $2`, orgID, badgeID)
+}
+
+func (r *KioskRepository) findMember(ctx context.Context, query, orgID, credential string) (string, error) {
+	var userID string
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, orgID, credential).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return userID, err
+}
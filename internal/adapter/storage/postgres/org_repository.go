@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/syst3mctl/check-in-api/internal/core/domain"
 	"github.com/syst3mctl/check-in-api/internal/core/port"
@@ -19,12 +20,12 @@ func NewOrgRepository(db *DB) port.OrgRepository {
 
 func (r *OrgRepository) CreateOrganization(ctx context.Context, org *domain.Organization) error {
 	query := `
-		INSERT INTO organizations (name, email, default_location_lat, default_location_long)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO organizations (name, email, default_location_lat, default_location_long, geofence_radius_meters, geofence_mode, network_mode, allowed_networks, location_rule)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id, created_at
 	`
 	executor := r.db.GetExecutor(ctx)
-	err := executor.QueryRow(ctx, query, org.Name, org.Email, org.DefaultLocationLat, org.DefaultLocationLong).
+	err := executor.QueryRow(ctx, query, org.Name, org.Email, org.DefaultLocationLat, org.DefaultLocationLong, org.GeofenceRadiusMeters, org.GeofenceMode, org.NetworkMode, org.AllowedNetworks, org.LocationRule).
 		Scan(&org.ID, &org.CreatedAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -44,14 +45,14 @@ func (r *OrgRepository) CreateOrganization(ctx context.Context, org *domain.Orga
 
 func (r *OrgRepository) GetOrganizationByID(ctx context.Context, id string) (*domain.Organization, error) {
 	query := `
-		SELECT id, name, email, default_location_lat, default_location_long, created_at
+		SELECT id, name, email, default_location_lat, default_location_long, geofence_radius_meters, geofence_mode, network_mode, allowed_networks, location_rule, created_at
 		FROM organizations
 		WHERE id = $1
 	`
 	var org domain.Organization
 	executor := r.db.GetExecutor(ctx)
 	err := executor.QueryRow(ctx, query, id).Scan(
-		&org.ID, &org.Name, &org.Email, &org.DefaultLocationLat, &org.DefaultLocationLong, &org.CreatedAt,
+		&org.ID, &org.Name, &org.Email, &org.DefaultLocationLat, &org.DefaultLocationLong, &org.GeofenceRadiusMeters, &org.GeofenceMode, &org.NetworkMode, &org.AllowedNetworks, &org.LocationRule, &org.CreatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -62,11 +63,12 @@ func (r *OrgRepository) GetOrganizationByID(ctx context.Context, id string) (*do
 func (r *OrgRepository) UpdateOrganization(ctx context.Context, org *domain.Organization) error {
 	query := `
 		UPDATE organizations
-		SET name = $2, email = $3, default_location_lat = $4, default_location_long = $5
+		SET name = $2, email = $3, default_location_lat = $4, default_location_long = $5, geofence_radius_meters = $6, geofence_mode = $7,
+		    network_mode = $8, allowed_networks = $9, location_rule = $10
 		WHERE id = $1
 	`
 	executor := r.db.GetExecutor(ctx)
-	_, err := executor.Exec(ctx, query, org.ID, org.Name, org.Email, org.DefaultLocationLat, org.DefaultLocationLong)
+	_, err := executor.Exec(ctx, query, org.ID, org.Name, org.Email, org.DefaultLocationLat, org.DefaultLocationLong, org.GeofenceRadiusMeters, org.GeofenceMode, org.NetworkMode, org.AllowedNetworks, org.LocationRule)
 	return err
 }
 
@@ -79,7 +81,7 @@ func (r *OrgRepository) DeleteOrganization(ctx context.Context, id string) error
 
 func (r *OrgRepository) ListOrganizations(ctx context.Context, userID string) ([]*domain.Organization, error) {
 	query := `
-		SELECT o.id, o.name, o.email, o.default_location_lat, o.default_location_long, o.created_at
+		SELECT o.id, o.name, o.email, o.default_location_lat, o.default_location_long, o.geofence_radius_meters, o.geofence_mode, o.network_mode, o.allowed_networks, o.location_rule, o.created_at
 		FROM organizations o
 		JOIN organization_members om ON o.id = om.org_id
 		WHERE om.user_id = $1
@@ -94,7 +96,7 @@ func (r *OrgRepository) ListOrganizations(ctx context.Context, userID string) ([
 	var orgs []*domain.Organization
 	for rows.Next() {
 		var org domain.Organization
-		if err := rows.Scan(&org.ID, &org.Name, &org.Email, &org.DefaultLocationLat, &org.DefaultLocationLong, &org.CreatedAt); err != nil {
+		if err := rows.Scan(&org.ID, &org.Name, &org.Email, &org.DefaultLocationLat, &org.DefaultLocationLong, &org.GeofenceRadiusMeters, &org.GeofenceMode, &org.NetworkMode, &org.AllowedNetworks, &org.LocationRule, &org.CreatedAt); err != nil {
 			return nil, err
 		}
 		orgs = append(orgs, &org)
@@ -132,26 +134,269 @@ func (r *OrgRepository) AddMember(ctx context.Context, member *domain.Organizati
 
 func (r *OrgRepository) CreateShift(ctx context.Context, shift *domain.Shift) error {
 	query := `
-		INSERT INTO shifts (org_id, name, start_time, end_time, timezone, allowed_late_minutes, working_days)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO shifts (org_id, name, start_time, end_time, timezone, allowed_late_minutes, allowed_early_leave_minutes, working_days, break_types, site_id, segments)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, created_at
 	`
+	breakTypes := shift.BreakTypes
+	if breakTypes == nil {
+		breakTypes = []domain.BreakType{}
+	}
+	segments := shift.Segments
+	if segments == nil {
+		segments = []domain.ShiftSegment{}
+	}
 	executor := r.db.GetExecutor(ctx)
-	return executor.QueryRow(ctx, query, shift.OrgID, shift.Name, shift.StartTime, shift.EndTime, shift.Timezone, shift.AllowedLateMinutes, shift.WorkingDays).
+	return executor.QueryRow(ctx, query, shift.OrgID, shift.Name, shift.StartTime, shift.EndTime, shift.Timezone, shift.AllowedLateMinutes, shift.AllowedEarlyLeaveMinutes, shift.WorkingDays, breakTypes, shift.SiteID, segments).
 		Scan(&shift.ID, &shift.CreatedAt)
 }
 
+func (r *OrgRepository) GetShiftByID(ctx context.Context, id string) (*domain.Shift, error) {
+	query := `SELECT ` + shiftColumns + ` FROM shifts WHERE id = $1`
+	var shift domain.Shift
+	executor := r.db.GetExecutor(ctx)
+	err := scanShift(executor.QueryRow(ctx, query, id), &shift)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
 func (r *OrgRepository) CreateGroup(ctx context.Context, group *domain.Group) error {
 	query := `
-		INSERT INTO groups (org_id, name, shift_id, manager_id)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO groups (org_id, name, shift_id, rotation_id, manager_id, site_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id, created_at
 	`
 	executor := r.db.GetExecutor(ctx)
-	return executor.QueryRow(ctx, query, group.OrgID, group.Name, group.ShiftID, group.ManagerID).
+	return executor.QueryRow(ctx, query, group.OrgID, group.Name, group.ShiftID, group.RotationID, group.ManagerID, group.SiteID).
 		Scan(&group.ID, &group.CreatedAt)
 }
 
+func (r *OrgRepository) CreateSite(ctx context.Context, site *domain.Site) error {
+	query := `
+		INSERT INTO sites (org_id, name, address, latitude, longitude, radius_meters, boundary, timezone)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, site.OrgID, site.Name, site.Address, site.Latitude, site.Longitude, site.RadiusMeters, site.Boundary, site.Timezone).
+		Scan(&site.ID, &site.CreatedAt)
+	return siteError(err)
+}
+
+func (r *OrgRepository) GetSiteByID(ctx context.Context, id string) (*domain.Site, error) {
+	query := `
+		SELECT id, org_id, name, COALESCE(address, ''), latitude, longitude, radius_meters, boundary, timezone, created_at
+		FROM sites
+		WHERE id = $1
+	`
+	var site domain.Site
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, id).Scan(
+		&site.ID, &site.OrgID, &site.Name, &site.Address, &site.Latitude, &site.Longitude, &site.RadiusMeters, &site.Boundary, &site.Timezone, &site.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &site, nil
+}
+
+func (r *OrgRepository) ListSites(ctx context.Context, orgID string) ([]*domain.Site, error) {
+	query := `
+		SELECT id, org_id, name, COALESCE(address, ''), latitude, longitude, radius_meters, boundary, timezone, created_at
+		FROM sites
+		WHERE org_id = $1
+		ORDER BY name
+	`
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sites []*domain.Site
+	for rows.Next() {
+		var site domain.Site
+		if err := rows.Scan(&site.ID, &site.OrgID, &site.Name, &site.Address, &site.Latitude, &site.Longitude, &site.RadiusMeters, &site.Boundary, &site.Timezone, &site.CreatedAt); err != nil {
+			return nil, err
+		}
+		sites = append(sites, &site)
+	}
+	return sites, rows.Err()
+}
+
+func (r *OrgRepository) UpdateSite(ctx context.Context, site *domain.Site) (bool, error) {
+	query := `
+		UPDATE sites
+		SET name = $3, address = NULLIF($4, ''), latitude = $5, longitude = $6, radius_meters = $7, boundary = $8, timezone = $9
+		WHERE id = $1 AND org_id = $2
+		RETURNING created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, site.ID, site.OrgID, site.Name, site.Address, site.Latitude, site.Longitude, site.RadiusMeters, site.Boundary, site.Timezone).
+		Scan(&site.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, siteError(err)
+	}
+	return true, nil
+}
+
+func (r *OrgRepository) DeleteSite(ctx context.Context, orgID, id string) (bool, error) {
+	query := `DELETE FROM sites WHERE id = $1 AND org_id = $2`
+	executor := r.db.GetExecutor(ctx)
+	tag, err := executor.Exec(ctx, query, id, orgID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// siteError maps a clash with another site's name to a DuplicateError.
+func siteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "sites_org_id_name_key" {
+		return &domain.DuplicateError{Field: "name"}
+	}
+	return err
+}
+
+func (r *OrgRepository) CreateScheduleAssignment(ctx context.Context, assignment *domain.ScheduleAssignment) error {
+	query := `
+		INSERT INTO schedule_assignments (org_id, user_id, shift_id, rotation_id, effective_from, effective_to)
+		VALUES ($1, $2, $3, $4, $5::date, $6::date)
+		RETURNING id, created_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	return executor.QueryRow(ctx, query, assignment.OrgID, assignment.UserID, assignment.ShiftID, assignment.RotationID, assignment.EffectiveFrom, assignment.EffectiveTo).
+		Scan(&assignment.ID, &assignment.CreatedAt)
+}
+
+func (r *OrgRepository) GetScheduleAssignmentByID(ctx context.Context, id string) (*domain.ScheduleAssignment, error) {
+	query := `
+		SELECT id, org_id, user_id, shift_id, rotation_id, effective_from::text, effective_to::text, created_at
+		FROM schedule_assignments
+		WHERE id = $1
+	`
+	var a domain.ScheduleAssignment
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, id).Scan(&a.ID, &a.OrgID, &a.UserID, &a.ShiftID, &a.RotationID, &a.EffectiveFrom, &a.EffectiveTo, &a.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *OrgRepository) ListScheduleAssignments(ctx context.Context, orgID, userID string) ([]*domain.ScheduleAssignment, error) {
+	query := `
+		SELECT id, org_id, user_id, shift_id, rotation_id, effective_from::text, effective_to::text, created_at
+		FROM schedule_assignments
+		WHERE org_id = $1 AND user_id = $2
+		ORDER BY effective_from
+	`
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assignments []*domain.ScheduleAssignment
+	for rows.Next() {
+		var a domain.ScheduleAssignment
+		if err := rows.Scan(&a.ID, &a.OrgID, &a.UserID, &a.ShiftID, &a.RotationID, &a.EffectiveFrom, &a.EffectiveTo, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, &a)
+	}
+	return assignments, rows.Err()
+}
+
+func (r *OrgRepository) SetScheduleAssignmentEnd(ctx context.Context, id string, effectiveTo *string) error {
+	query := `UPDATE schedule_assignments SET effective_to = $2::date WHERE id = $1`
+	executor := r.db.GetExecutor(ctx)
+	_, err := executor.Exec(ctx, query, id, effectiveTo)
+	return err
+}
+
+func (r *OrgRepository) DeleteScheduleAssignment(ctx context.Context, id string) error {
+	query := `DELETE FROM schedule_assignments WHERE id = $1`
+	executor := r.db.GetExecutor(ctx)
+	_, err := executor.Exec(ctx, query, id)
+	return err
+}
+
+func (r *OrgRepository) CreateRotation(ctx context.Context, rotation *domain.Rotation) error {
+	// The rotation and its days are written in one statement
+	query := `
+		WITH rotation AS (
+			INSERT INTO rotations (org_id, name, anchor_date, timezone)
+			VALUES ($1, $2, $3::date, $5)
+			RETURNING id, created_at
+		), days AS (
+			INSERT INTO rotation_days (rotation_id, position, shift_id)
+			SELECT rotation.id, d.position, d.shift_id
+			FROM rotation, unnest($4::uuid[]) WITH ORDINALITY AS d(shift_id, position)
+		)
+		SELECT id, created_at FROM rotation
+	`
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, rotation.OrgID, rotation.Name, rotation.AnchorDate, rotation.Days, rotation.Timezone).
+		Scan(&rotation.ID, &rotation.CreatedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "rotations_org_id_name_key" {
+		return &domain.DuplicateError{Field: "name"}
+	}
+	return err
+}
+
+func (r *OrgRepository) GetRotationByID(ctx context.Context, id string) (*domain.Rotation, error) {
+	query := `SELECT ` + rotationColumns + ` FROM rotations WHERE id = $1`
+	var rotation domain.Rotation
+	executor := r.db.GetExecutor(ctx)
+	err := scanRotation(executor.QueryRow(ctx, query, id), &rotation)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rotation, nil
+}
+
+func (r *OrgRepository) ListRotations(ctx context.Context, orgID string) ([]*domain.Rotation, error) {
+	query := `SELECT ` + rotationColumns + ` FROM rotations WHERE org_id = $1 ORDER BY name`
+	executor := r.db.GetExecutor(ctx)
+	rows, err := executor.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rotations []*domain.Rotation
+	for rows.Next() {
+		var rotation domain.Rotation
+		if err := scanRotation(rows, &rotation); err != nil {
+			return nil, err
+		}
+		rotations = append(rotations, &rotation)
+	}
+	return rotations, rows.Err()
+}
+
 func (r *OrgRepository) UpdateMemberGroup(ctx context.Context, orgID, userID, groupID string) error {
 	query := `
 		UPDATE organization_members
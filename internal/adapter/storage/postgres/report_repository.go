@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
 
 	"github.com/syst3mctl/check-in-api/internal/core/domain"
 	"github.com/syst3mctl/check-in-api/internal/core/port"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type ReportRepository struct {
@@ -15,22 +18,57 @@ func NewReportRepository(db *DB) port.ReportRepository {
 	return &ReportRepository{db: db}
 }
 
-func (r *ReportRepository) GetGroupPerformance(ctx context.Context, groupID string) (*domain.GroupPerformanceReport, error) {
+// GetGroupPerformance counts the group's attendance for shift instances, each
+// segment of a split shift counted apart, dated within the inclusive [from, to]
+// range. AttendanceRate and ExpectedShifts are left for the caller, which
+// knows the shift schedule.
+func (r *ReportRepository) GetGroupPerformance(ctx context.Context, groupID, from, to string) (*domain.GroupPerformanceReport, error) {
 	query := `
-		SELECT g.name, COALESCE(s.name, 'No Shift'),
+		SELECT g.name, COALESCE(s.name, r.name, 'No Shift'),
 		       (SELECT COUNT(*) FROM attendance a 
-		        JOIN organization_members om ON a.user_id = om.user_id 
-		        WHERE om.group_id = g.id AND a.status = 'LATE') as late_count
+		        JOIN organization_members om ON a.user_id = om.user_id AND a.org_id = om.org_id
+		        WHERE om.group_id = g.id AND a.status = 'LATE'
+		          AND a.shift_date BETWEEN $2::date AND $3::date) as late_count,
+		       (SELECT COUNT(DISTINCT (a.user_id, a.shift_date, a.shift_segment)) FROM attendance a
+		        JOIN organization_members om ON a.user_id = om.user_id AND a.org_id = om.org_id
+		        WHERE om.group_id = g.id AND a.status IN ('PRESENT', 'LATE')
+		          AND a.shift_date BETWEEN $2::date AND $3::date) as attended_count,
+		       (SELECT COUNT(*) FROM attendance a
+		        JOIN organization_members om ON a.user_id = om.user_id AND a.org_id = om.org_id
+		        WHERE om.group_id = g.id AND a.source = 'MANUAL'
+		          AND a.shift_date BETWEEN $2::date AND $3::date) as manual_count
 		FROM groups g
 		LEFT JOIN shifts s ON g.shift_id = s.id
+		LEFT JOIN rotations r ON g.rotation_id = r.id
 		WHERE g.id = $1
 	`
-	report := &domain.GroupPerformanceReport{}
+	report := &domain.GroupPerformanceReport{From: from, To: to}
 	executor := r.db.GetExecutor(ctx)
-	err := executor.QueryRow(ctx, query, groupID).Scan(&report.GroupName, &report.AssignedShift, &report.TotalLateCheckins)
+	err := executor.QueryRow(ctx, query, groupID, from, to).Scan(&report.GroupName, &report.AssignedShift, &report.TotalLateCheckins, &report.AttendedShifts, &report.ManualEntries)
 	if err != nil {
 		return nil, err
 	}
-	report.AttendanceRate = "98%" // Placeholder logic
 	return report, nil
 }
+
+func (r *ReportRepository) GetGroupSchedule(ctx context.Context, groupID string) (*domain.Schedule, error) {
+	query := `SELECT shift_id, rotation_id FROM groups WHERE id = $1`
+	var shiftID, rotationID *string
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, groupID).Scan(&shiftID, &rotationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return loadSchedule(ctx, executor, shiftID, rotationID, nil)
+}
+
+func (r *ReportRepository) CountGroupMembers(ctx context.Context, groupID string) (int, error) {
+	query := `SELECT COUNT(*) FROM organization_members WHERE group_id = $1`
+	var count int
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, groupID).Scan(&count)
+	return count, err
+}
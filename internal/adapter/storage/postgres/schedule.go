@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+)
+
+const shiftColumns = `id, org_id, name, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), timezone, allowed_late_minutes, allowed_early_leave_minutes, working_days, COALESCE(break_types, '[]'), segments, site_id, created_at`
+
+// rotationColumns selects a rotation's days in cycle order from
+// rotation_days.
+const rotationColumns = `id, org_id, name, anchor_date::text, ARRAY(SELECT d.shift_id::text FROM rotation_days d WHERE d.rotation_id = rotations.id ORDER BY d.position), timezone, created_at`
+
+func scanRotation(row pgx.Row, rotation *domain.Rotation) error {
+	return row.Scan(&rotation.ID, &rotation.OrgID, &rotation.Name, &rotation.AnchorDate, &rotation.Days, &rotation.Timezone, &rotation.CreatedAt)
+}
+
+func scanShift(row pgx.Row, shift *domain.Shift) error {
+	return row.Scan(
+		&shift.ID, &shift.OrgID, &shift.Name, &shift.StartTime, &shift.EndTime, &shift.Timezone, &shift.AllowedLateMinutes, &shift.AllowedEarlyLeaveMinutes, &shift.WorkingDays, &shift.BreakTypes, &shift.Segments, &shift.SiteID, &shift.CreatedAt,
+	)
+}
+
+// loadSchedule returns the shift, or the rotation with its Shifts loaded,
+// whichever ID is set; it returns nil if neither is. Loaded schedules are kept
+// in cache, which may be nil, so callers resolving many members share them.
+func loadSchedule(ctx context.Context, executor DBExecutor, shiftID, rotationID *string, cache map[string]*domain.Schedule) (*domain.Schedule, error) {
+	var key string
+	switch {
+	case rotationID != nil:
+		key = "rotation:" + *rotationID
+	case shiftID != nil:
+		key = "shift:" + *shiftID
+	default:
+		return nil, nil
+	}
+	if schedule, ok := cache[key]; ok {
+		return schedule, nil
+	}
+
+	schedule := &domain.Schedule{}
+	if rotationID != nil {
+		rotation, err := loadRotation(ctx, executor, *rotationID)
+		if err != nil {
+			return nil, err
+		}
+		schedule.Rotation = rotation
+	} else {
+		var shift domain.Shift
+		err := scanShift(executor.QueryRow(ctx, `SELECT `+shiftColumns+` FROM shifts WHERE id = $1`, *shiftID), &shift)
+		if err != nil {
+			return nil, fmt.Errorf("load shift %s: %w", *shiftID, err)
+		}
+		schedule.Shift = &shift
+	}
+
+	if cache != nil {
+		cache[key] = schedule
+	}
+	return schedule, nil
+}
+
+// loadRotation returns the rotation with the shifts it uses.
+func loadRotation(ctx context.Context, executor DBExecutor, id string) (*domain.Rotation, error) {
+	var rotation domain.Rotation
+	err := scanRotation(executor.QueryRow(ctx, `SELECT `+rotationColumns+` FROM rotations WHERE id = $1`, id), &rotation)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("rotation %s not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var shiftIDs []string
+	for _, shiftID := range rotation.Days {
+		if shiftID != nil {
+			shiftIDs = append(shiftIDs, *shiftID)
+		}
+	}
+	rows, err := executor.Query(ctx, `SELECT `+shiftColumns+` FROM shifts WHERE id = ANY($1::uuid[])`, shiftIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rotation.Shifts = make(map[string]*domain.Shift)
+	for rows.Next() {
+		var shift domain.Shift
+		if err := scanShift(rows, &shift); err != nil {
+			return nil, err
+		}
+		rotation.Shifts[shift.ID] = &shift
+	}
+	return &rotation, rows.Err()
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type SyncRepository struct {
+	db *DB
+}
+
+func NewSyncRepository(db *DB) port.SyncRepository {
+	return &SyncRepository{db: db}
+}
+
+func (r *SyncRepository) CreateOfflineEvent(ctx context.Context, event *domain.OfflineEvent) error {
+	query := `
+		INSERT INTO offline_events (user_id, client_event_id, type, org_id, attendance_id, captured_at, clock_offset_seconds, event_time, flagged, flag_reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, received_at
+	`
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, event.UserID, event.ClientEventID, event.Type, event.OrgID, event.AttendanceID, event.CapturedAt, event.ClockOffsetSeconds, event.EventTime, event.Flagged, event.FlagReason).
+		Scan(&event.ID, &event.ReceivedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_offline_events_client_event" {
+			return &domain.DuplicateError{Field: "client event"}
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *SyncRepository) GetOfflineEvent(ctx context.Context, userID, clientEventID string) (*domain.OfflineEvent, error) {
+	query := `
+		SELECT id, user_id, client_event_id, type, org_id, attendance_id, captured_at, clock_offset_seconds, event_time, flagged, COALESCE(flag_reason, ''), received_at
+		FROM offline_events
+		WHERE user_id = $1 AND client_event_id = $2
+	`
+	e := &domain.OfflineEvent{Status: domain.SyncStatusAccepted}
+	executor := r.db.GetExecutor(ctx)
+	err := executor.QueryRow(ctx, query, userID, clientEventID).
+		Scan(&e.ID, &e.UserID, &e.ClientEventID, &e.Type, &e.OrgID, &e.AttendanceID, &e.CapturedAt, &e.ClockOffsetSeconds, &e.EventTime, &e.Flagged, &e.FlagReason, &e.ReceivedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
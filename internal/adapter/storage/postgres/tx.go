@@ -9,8 +9,13 @@ import (
 
 type txKey struct{}
 
-// RunInTx executes the given function within a database transaction.
+// RunInTx executes the given function within a database transaction. Calls
+// made inside another RunInTx join its transaction.
 func (db *DB) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := db.Pool.Begin(ctx)
 	if err != nil {
 		return err
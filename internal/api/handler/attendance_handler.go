@@ -2,7 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/syst3mctl/check-in-api/internal/core/domain"
 	"github.com/syst3mctl/check-in-api/internal/core/service"
@@ -31,6 +34,7 @@ func NewAttendanceHandler(svc *service.AttendanceService) *AttendanceHandler {
 // @Param request body domain.Task true "Task Request"
 // @Success 201 {object} domain.Task
 // @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 404 {object} domain.ErrorResponse "site not found"
 // @Failure 500 {object} domain.ErrorResponse "internal server error"
 // @Router /organizations/{org_id}/tasks [post]
 func (h *AttendanceHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
@@ -49,6 +53,10 @@ func (h *AttendanceHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 
 	task, err := h.svc.CreateTask(r.Context(), &req)
 	if err != nil {
+		if err.Error() == "site not found" {
+			response.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -56,16 +64,164 @@ func (h *AttendanceHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	response.WriteJSON(w, http.StatusCreated, task)
 }
 
+// CreateCheckpoint godoc
+// @Summary Create a QR checkpoint
+// @Description Add a checkpoint whose screen shows a rotating QR code members scan to check in (Owner/Manager only)
+// @Tags Checkpoint
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param request body domain.Checkpoint true "Checkpoint Request"
+// @Success 201 {object} domain.Checkpoint
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/checkpoints [post]
+func (h *AttendanceHandler) CreateCheckpoint(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+	var req domain.Checkpoint
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	checkpoint, err := h.svc.CreateCheckpoint(r.Context(), userID, orgID, &req)
+	if err != nil {
+		if err.Error() == "unauthorized" {
+			response.WriteError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, checkpoint)
+}
+
+// ListCheckpoints godoc
+// @Summary List QR checkpoints
+// @Description List the organization's checkpoints (Owner/Manager only)
+// @Tags Checkpoint
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Success 200 {array} domain.Checkpoint
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/checkpoints [get]
+func (h *AttendanceHandler) ListCheckpoints(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+
+	checkpoints, err := h.svc.ListCheckpoints(r.Context(), userID, orgID)
+	if err != nil {
+		if err.Error() == "unauthorized" {
+			response.WriteError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, checkpoints)
+}
+
+// GetCheckpointToken godoc
+// @Summary Get the current QR token
+// @Description Get the token the checkpoint's screen should display as a QR code, and when it rotates (Owner/Manager only)
+// @Tags Checkpoint
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param checkpoint_id path string true "Checkpoint ID"
+// @Success 200 {object} domain.CheckpointToken
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "checkpoint not found"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/checkpoints/{checkpoint_id}/token [get]
+func (h *AttendanceHandler) GetCheckpointToken(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	checkpointID := chi.URLParam(r, "checkpoint_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(checkpointID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "checkpoint not found")
+		return
+	}
+
+	token, err := h.svc.CheckpointToken(r.Context(), userID, orgID, checkpointID)
+	if err != nil {
+		switch err.Error() {
+		case "unauthorized":
+			response.WriteError(w, http.StatusForbidden, err.Error())
+		case "checkpoint not found":
+			response.WriteError(w, http.StatusNotFound, err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, token)
+}
+
+// ListCheckInViolations godoc
+// @Summary List refused check-ins
+// @Description List the organization's latest check-ins refused by its geofence or network allowlist, newest first (Owner/Manager only)
+// @Tags Attendance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param limit query int false "Number of violations (default 20, max 100)"
+// @Success 200 {array} domain.CheckInViolation
+// @Failure 400 {object} domain.ErrorResponse "invalid query parameters"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/check-in-violations [get]
+func (h *AttendanceHandler) ListCheckInViolations(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+	limit, err := parseLimitParam(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	violations, err := h.svc.ListCheckInViolations(r.Context(), userID, orgID, limit)
+	if err != nil {
+		if err.Error() == "unauthorized" {
+			response.WriteError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, violations)
+}
+
 // CheckIn godoc
 // @Summary Check-in
-// @Description Perform a check-in (General or Task-based)
+// @Description Perform a check-in (General or Task-based). A QR token scanned at one of the organization's checkpoints can be sent in place of coordinates for general check-ins.
 // @Tags Attendance
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param request body domain.CheckInRequest true "Check-In Request"
+// @Param Idempotency-Key header string false "Key for safely retrying the request"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "outside the allowed geofence or networks"
+// @Failure 409 {object} domain.ErrorResponse "a concurrent check-in opened a session first, or the QR token was already used"
 // @Router /attendance/check-in [post]
 func (h *AttendanceHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("user_id").(string)
@@ -81,44 +237,444 @@ func (h *AttendanceHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
 	}
 
 	att := &domain.Attendance{
-		TaskID:       req.TaskID,
-		LocationLat:  req.Latitude,
-		LocationLong: req.Longitude,
-		Note:         req.Note,
+		TaskID:           req.TaskID,
+		LocationLat:      req.Latitude,
+		LocationLong:     req.Longitude,
+		LocationAccuracy: req.Accuracy,
+		LocationProvider: req.Provider,
+		MockLocation:     req.MockLocation,
+		Note:             req.Note,
 	}
+	att.ClientIP, _ = r.Context().Value("client_ip").(string)
 
-	result, err := h.svc.CheckIn(r.Context(), userID, req.OrganizationID, att)
+	result, err := h.svc.CheckIn(r.Context(), userID, req.OrganizationID, att, req.QRToken)
 	if err != nil {
+		var geoErr *domain.GeofenceError
+		if errors.As(err, &geoErr) {
+			response.WriteError(w, http.StatusForbidden, geoErr.Error())
+			return
+		}
+		var netErr *domain.NetworkError
+		if errors.As(err, &netErr) {
+			response.WriteError(w, http.StatusForbidden, netErr.Error())
+			return
+		}
+		var dupErr *domain.DuplicateError
+		if errors.As(err, &dupErr) {
+			response.WriteError(w, http.StatusConflict, "already checked in")
+			return
+		}
+		if err.Error() == "qr token already used" {
+			response.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	response.WriteJSON(w, http.StatusOK, map[string]interface{}{
-		"status":          "success",
-		"check_in_time":   result.CheckInTime,
-		"attendance_type": result.Type,
-		"shift_applied":   result.ShiftApplied,
-		"is_late":         result.Status == "LATE",
+		"status":            "success",
+		"check_in_time":     result.CheckInTime,
+		"attendance_type":   result.Type,
+		"proof_type":        result.ProofType,
+		"shift_applied":     result.ShiftApplied,
+		"attendance_status": result.Status,
+		"is_late":           result.Status == domain.AttendanceStatusLate,
+		"late_minutes":      result.LateMinutes,
+		"distance_meters":   result.DistanceMeters,
+		"flagged":           result.Flagged,
+		"flag_reason":       result.FlagReason,
+		"risk_score":        result.RiskScore,
+		"risk_reasons":      result.RiskReasons,
 	})
 }
 
 // CheckOut godoc
 // @Summary Check-out
-// @Description Perform a check-out
+// @Description Perform a check-out. The location, when given, is checked against the same geofence as the check-in.
 // @Tags Attendance
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Success 200 {object} map[string]string
+// @Param request body domain.CheckOutRequest false "Check-Out Request"
+// @Param Idempotency-Key header string false "Key for safely retrying the request"
+// @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} domain.ErrorResponse "bad request"
+// @Failure 403 {object} domain.ErrorResponse "outside the allowed geofence"
+// @Failure 404 {object} domain.ErrorResponse "attendance not found"
 // @Router /attendance/check-out [post]
 func (h *AttendanceHandler) CheckOut(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("user_id").(string)
 
-	if err := h.svc.CheckOut(r.Context(), userID); err != nil {
+	// The body is optional, so clients that send none check out without a location.
+	var req domain.CheckOutRequest
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			response.WriteError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	result, err := h.svc.CheckOut(r.Context(), userID, &req)
+	if err != nil {
+		var geoErr *domain.GeofenceError
+		if errors.As(err, &geoErr) {
+			response.WriteError(w, http.StatusForbidden, geoErr.Error())
+			return
+		}
+		if err.Error() == "attendance not found" {
+			response.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"status":                    "success",
+		"check_out_time":            result.CheckOutTime,
+		"check_out_status":          result.CheckOutStatus,
+		"early_leave_minutes":       result.EarlyLeaveMinutes,
+		"overtime_minutes":          result.OvertimeMinutes,
+		"check_out_distance_meters": result.CheckOutDistanceMeters,
+		"flagged":                   result.Flagged,
+		"flag_reason":               result.FlagReason,
+	})
+}
+
+// StartBreak godoc
+// @Summary Start a break
+// @Description Start a break in the open attendance session. Unpaid breaks are subtracted from worked time.
+// @Tags Attendance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body domain.StartBreakRequest false "Organization of the session and break type; both may be omitted"
+// @Param Idempotency-Key header string false "Key for safely retrying the request"
+// @Success 201 {object} domain.AttendanceBreak
+// @Failure 400 {object} domain.ErrorResponse "not checked in or unknown break type"
+// @Failure 409 {object} domain.ErrorResponse "already on break"
+// @Router /attendance/breaks/start [post]
+func (h *AttendanceHandler) StartBreak(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	// The break type is optional, so an empty body is allowed.
+	var req domain.StartBreakRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	brk, err := h.svc.StartBreak(r.Context(), userID, req.OrganizationID, req.Type)
+	if err != nil {
+		var dupErr *domain.DuplicateError
+		if errors.As(err, &dupErr) || err.Error() == "already on break" {
+			response.WriteError(w, http.StatusConflict, "already on break")
+			return
+		}
 		response.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	response.WriteJSON(w, http.StatusOK, map[string]string{"status": "success"})
+	response.WriteJSON(w, http.StatusCreated, brk)
+}
+
+// EndBreak godoc
+// @Summary End a break
+// @Description End the running break in the open attendance session
+// @Tags Attendance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body domain.EndBreakRequest false "Organization of the session"
+// @Param Idempotency-Key header string false "Key for safely retrying the request"
+// @Success 200 {object} domain.AttendanceBreak
+// @Failure 400 {object} domain.ErrorResponse "not checked in or not on break"
+// @Router /attendance/breaks/end [post]
+func (h *AttendanceHandler) EndBreak(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	// The organization is optional, so an empty body is allowed.
+	var req domain.EndBreakRequest
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			response.WriteError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	brk, err := h.svc.EndBreak(r.Context(), userID, req.OrganizationID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, brk)
+}
+
+// ListMyAttendance godoc
+// @Summary List my attendance
+// @Description List the authenticated user's attendance records, newest first
+// @Tags Attendance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param from query string false "Earliest check-in (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "Latest check-in (YYYY-MM-DD inclusive, or RFC 3339 exclusive)"
+// @Param organization_id query string false "Organization ID"
+// @Param type query string false "Attendance type" Enums(GENERAL, TASK)
+// @Param status query string false "Attendance status" Enums(PRESENT, LATE, ABSENT, NON_WORKING_DAY)
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} domain.AttendancePage
+// @Failure 400 {object} domain.ErrorResponse "invalid query parameters"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /me/attendance [get]
+func (h *AttendanceHandler) ListMyAttendance(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	filter, err := parseAttendanceFilter(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.svc.ListMyAttendance(r.Context(), userID, filter, r.URL.Query().Get("cursor"))
+	if err != nil {
+		if err.Error() == "invalid cursor" {
+			response.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, page)
+}
+
+// parseAttendanceFilter reads the query parameters shared by attendance listings.
+func parseAttendanceFilter(r *http.Request) (domain.AttendanceFilter, error) {
+	var filter domain.AttendanceFilter
+	var err error
+	query := r.URL.Query()
+
+	if filter.From, err = parseTimeParam(r, "from", false); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTimeParam(r, "to", true); err != nil {
+		return filter, err
+	}
+	if filter.Limit, err = parseLimitParam(r); err != nil {
+		return filter, err
+	}
+
+	filter.OrgID = query.Get("organization_id")
+	if filter.OrgID != "" && !validator.IsValid(filter.OrgID, "uuid") {
+		return filter, errors.New("organization_id must be a valid UUID")
+	}
+	filter.Type = query.Get("type")
+	switch filter.Type {
+	case "", "GENERAL", "TASK":
+	default:
+		return filter, errors.New("type must be one of: GENERAL, TASK")
+	}
+	filter.Status = query.Get("status")
+	switch filter.Status {
+	case "", domain.AttendanceStatusPresent, domain.AttendanceStatusLate, domain.AttendanceStatusAbsent, domain.AttendanceStatusNonWorkingDay:
+	default:
+		return filter, errors.New("status must be one of: PRESENT, LATE, ABSENT, NON_WORKING_DAY")
+	}
+	return filter, nil
+}
+
+// ListTeamAttendance godoc
+// @Summary List team attendance
+// @Description List attendance records for the organization's members, newest first (Owner/Manager only)
+// @Tags Attendance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param group_id query string false "Only members of this group"
+// @Param site_id query string false "Only sessions checked in at this site"
+// @Param user_id query string false "Only this member"
+// @Param from query string false "Earliest check-in (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "Latest check-in (YYYY-MM-DD inclusive, or RFC 3339 exclusive)"
+// @Param type query string false "Attendance type" Enums(GENERAL, TASK)
+// @Param status query string false "Attendance status" Enums(PRESENT, LATE, ABSENT, NON_WORKING_DAY)
+// @Param open query bool false "Only open (true) or closed (false) sessions"
+// @Param min_risk_score query int false "Only records with at least this location risk score (1-100)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} domain.TeamAttendancePage
+// @Failure 400 {object} domain.ErrorResponse "invalid query parameters"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/attendance [get]
+func (h *AttendanceHandler) ListTeamAttendance(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+	query := r.URL.Query()
+
+	filter, err := parseAttendanceFilter(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.GroupID = query.Get("group_id")
+	if filter.GroupID != "" && !validator.IsValid(filter.GroupID, "uuid") {
+		response.WriteError(w, http.StatusBadRequest, "group_id must be a valid UUID")
+		return
+	}
+	filter.SiteID = query.Get("site_id")
+	if filter.SiteID != "" && !validator.IsValid(filter.SiteID, "uuid") {
+		response.WriteError(w, http.StatusBadRequest, "site_id must be a valid UUID")
+		return
+	}
+	filter.UserID = query.Get("user_id")
+	if filter.UserID != "" && !validator.IsValid(filter.UserID, "uuid") {
+		response.WriteError(w, http.StatusBadRequest, "user_id must be a valid UUID")
+		return
+	}
+	if v := query.Get("open"); v != "" {
+		open, err := strconv.ParseBool(v)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "open must be true or false")
+			return
+		}
+		filter.Open = &open
+	}
+	if v := query.Get("min_risk_score"); v != "" {
+		score, err := strconv.Atoi(v)
+		if err != nil || score < 1 || score > 100 {
+			response.WriteError(w, http.StatusBadRequest, "min_risk_score must be an integer from 1 to 100")
+			return
+		}
+		filter.MinRiskScore = score
+	}
+
+	page, err := h.svc.ListTeamAttendance(r.Context(), userID, orgID, filter, query.Get("cursor"))
+	if err != nil {
+		switch err.Error() {
+		case "unauthorized":
+			response.WriteError(w, http.StatusForbidden, err.Error())
+		case "invalid cursor":
+			response.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, page)
+}
+
+// CreateManualAttendance godoc
+// @Summary Create a manual attendance record
+// @Description Record a complete session on behalf of a member, e.g. after a dead phone battery (Owner/Manager only)
+// @Tags Attendance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param request body domain.ManualAttendanceRequest true "Manual Attendance Request"
+// @Param Idempotency-Key header string false "Key for safely retrying the request"
+// @Success 201 {object} domain.Attendance
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "task not found"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/attendance [post]
+func (h *AttendanceHandler) CreateManualAttendance(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+	var req domain.ManualAttendanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	att, err := h.svc.CreateManualAttendance(r.Context(), userID, orgID, &req)
+	if err != nil {
+		writeManualAttendanceError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, att)
+}
+
+// EditAttendance godoc
+// @Summary Edit an attendance record
+// @Description Replace the check-in and check-out times of a member's attendance record. The record is marked MANUAL (Owner/Manager only)
+// @Tags Attendance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param attendance_id path string true "Attendance ID"
+// @Param request body domain.AttendanceEditRequest true "Attendance Edit Request"
+// @Param Idempotency-Key header string false "Key for safely retrying the request"
+// @Success 200 {object} domain.Attendance
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "attendance not found"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/attendance/{attendance_id} [put]
+func (h *AttendanceHandler) EditAttendance(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	attendanceID := chi.URLParam(r, "attendance_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(attendanceID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "attendance not found")
+		return
+	}
+
+	var req domain.AttendanceEditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	att, err := h.svc.EditAttendance(r.Context(), userID, orgID, attendanceID, &req)
+	if err != nil {
+		writeManualAttendanceError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, att)
+}
+
+func writeManualAttendanceError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "unauthorized":
+		response.WriteError(w, http.StatusForbidden, err.Error())
+	case "attendance not found", "task not found":
+		response.WriteError(w, http.StatusNotFound, err.Error())
+	case "attendance times cannot be in the future", "check-out time must be after check-in time":
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
 }
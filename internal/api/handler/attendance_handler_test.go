@@ -4,15 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/syst3mctl/check-in-api/internal/core/domain"
 	"github.com/syst3mctl/check-in-api/internal/core/service"
+	"github.com/syst3mctl/check-in-api/internal/pkg/qrtoken"
 )
 
 // MockAttendanceRepository is a mock implementation of port.AttendanceRepository
@@ -33,6 +37,32 @@ func (m *MockAttendanceRepository) GetTaskByID(ctx context.Context, id string) (
 	return args.Get(0).(*domain.Task), args.Error(1)
 }
 
+func (m *MockAttendanceRepository) CreateCheckpoint(ctx context.Context, checkpoint *domain.Checkpoint) error {
+	args := m.Called(ctx, checkpoint)
+	return args.Error(0)
+}
+
+func (m *MockAttendanceRepository) GetCheckpointByID(ctx context.Context, id string) (*domain.Checkpoint, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Checkpoint), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) ListCheckpoints(ctx context.Context, orgID string) ([]*domain.Checkpoint, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Checkpoint), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) UseCheckpointToken(ctx context.Context, checkpointID, userID string, window int64) error {
+	args := m.Called(ctx, checkpointID, userID, window)
+	return args.Error(0)
+}
+
 func (m *MockAttendanceRepository) CreateAttendance(ctx context.Context, attendance *domain.Attendance) error {
 	args := m.Called(ctx, attendance)
 	return args.Error(0)
@@ -43,32 +73,214 @@ func (m *MockAttendanceRepository) UpdateAttendance(ctx context.Context, attenda
 	return args.Error(0)
 }
 
-func (m *MockAttendanceRepository) GetLatestAttendance(ctx context.Context, userID string) (*domain.Attendance, error) {
-	args := m.Called(ctx, userID)
+func (m *MockAttendanceRepository) GetAttendanceByID(ctx context.Context, id string) (*domain.Attendance, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Attendance), args.Error(1)
 }
 
-func (m *MockAttendanceRepository) GetMemberGroup(ctx context.Context, orgID, userID string) (*domain.Group, *domain.Shift, error) {
-	args := m.Called(ctx, orgID, userID)
+func (m *MockAttendanceRepository) GetLastPunchLocation(ctx context.Context, userID string, before time.Time) (*domain.PunchLocation, error) {
+	args := m.Called(ctx, userID, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PunchLocation), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) CoordinatesUsedByOthers(ctx context.Context, userID string, lat, long float64, since time.Time) (bool, error) {
+	args := m.Called(ctx, userID, lat, long, since)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) CreateCheckInViolation(ctx context.Context, violation *domain.CheckInViolation) error {
+	args := m.Called(ctx, violation)
+	return args.Error(0)
+}
+
+func (m *MockAttendanceRepository) ListCheckInViolations(ctx context.Context, orgID string, limit int) ([]*domain.CheckInViolation, error) {
+	args := m.Called(ctx, orgID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CheckInViolation), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) SetAttendancePhoto(ctx context.Context, attendance *domain.Attendance) (bool, error) {
+	args := m.Called(ctx, attendance)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) ListOpenAttendance(ctx context.Context, userID, orgID string) ([]*domain.Attendance, error) {
+	args := m.Called(ctx, userID, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Attendance), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) ListAttendance(ctx context.Context, filter domain.AttendanceFilter) ([]*domain.Attendance, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Attendance), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) ListOrgAttendance(ctx context.Context, filter domain.AttendanceFilter) ([]*domain.AttendanceDetail, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AttendanceDetail), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) GetOpenBreak(ctx context.Context, attendanceID string) (*domain.AttendanceBreak, error) {
+	args := m.Called(ctx, attendanceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttendanceBreak), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) CreateBreak(ctx context.Context, brk *domain.AttendanceBreak) error {
+	args := m.Called(ctx, brk)
+	return args.Error(0)
+}
+
+func (m *MockAttendanceRepository) EndBreak(ctx context.Context, attendanceID string, at time.Time) (*domain.AttendanceBreak, error) {
+	args := m.Called(ctx, attendanceID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttendanceBreak), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) GetMemberGroup(ctx context.Context, orgID, userID string, at time.Time) (*domain.Group, *domain.Schedule, error) {
+	args := m.Called(ctx, orgID, userID, at)
 	group := args.Get(0)
-	shift := args.Get(1)
+	schedule := args.Get(1)
 
 	var g *domain.Group
 	if group != nil {
 		g = group.(*domain.Group)
 	}
 
-	var s *domain.Shift
-	if shift != nil {
-		s = shift.(*domain.Shift)
+	var s *domain.Schedule
+	if schedule != nil {
+		s = schedule.(*domain.Schedule)
 	}
 
 	return g, s, args.Error(2)
 }
 
+func (m *MockAttendanceRepository) ListScheduledMembers(ctx context.Context, since time.Time) ([]*domain.ScheduledMember, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ScheduledMember), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) CreateAbsence(ctx context.Context, attendance *domain.Attendance) (bool, error) {
+	args := m.Called(ctx, attendance)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) ListStaleSessions(ctx context.Context, shiftEndedBefore, checkedInBefore time.Time) ([]*domain.Attendance, error) {
+	args := m.Called(ctx, shiftEndedBefore, checkedInBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Attendance), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) CloseAttendance(ctx context.Context, attendance *domain.Attendance) (bool, error) {
+	args := m.Called(ctx, attendance)
+	return args.Bool(0), args.Error(1)
+}
+
+// geofencedOrg returns an organization with a 100m geofence around its default location.
+func geofencedOrg(mode string) *domain.Organization {
+	return &domain.Organization{
+		ID:                   "123e4567-e89b-12d3-a456-426614174000",
+		DefaultLocationLat:   41.7151,
+		DefaultLocationLong:  44.8271,
+		GeofenceRadiusMeters: 100,
+		GeofenceMode:         mode,
+	}
+}
+
+// networkOrg returns an organization that only allows check-ins from
+// 10.0.0.0/8 and, when geofenceMode is set, from near its default location.
+func networkOrg(mode, geofenceMode, rule string) *domain.Organization {
+	org := geofencedOrg(geofenceMode)
+	org.NetworkMode = mode
+	org.AllowedNetworks = []string{"10.0.0.0/8"}
+	org.LocationRule = rule
+	return org
+}
+
+// middayShift returns a "Morning" shift whose timezone is chosen so that the
+// current local time is between 12:00 and 13:00, keeping lateness checks
+// independent of when the tests run. When workingToday is false, today's
+// weekday is left out of the shift's working days.
+func middayShift(startTime string, allowedLateMinutes int, workingToday bool) *domain.Shift {
+	now := time.Now().UTC()
+	offset := 12 - now.Hour()
+	local := now.In(time.FixedZone("", offset*3600))
+	today := strings.ToUpper(local.Weekday().String()[:3])
+
+	var days []string
+	for _, day := range []string{"MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"} {
+		if day != today || workingToday {
+			days = append(days, day)
+		}
+	}
+
+	return &domain.Shift{
+		Name:               "Morning",
+		StartTime:          startTime,
+		EndTime:            "18:00",
+		Timezone:           fmt.Sprintf("Etc/GMT%+d", -offset), // POSIX-style zones invert the sign
+		AllowedLateMinutes: allowedLateMinutes,
+		WorkingDays:        days,
+	}
+}
+
+// middayRotation returns a 2-on/2-off "Panama" rotation of middayShift. Today
+// is the first day on when workingToday is true, otherwise the first day off.
+// The shift's own working days leave today out, which the rotation overrides.
+func middayRotation(workingToday bool) *domain.Schedule {
+	shift := middayShift("12:00", 60, false)
+	shift.ID = "shift-morning"
+	loc, _ := time.LoadLocation(shift.Timezone)
+	anchor := time.Now().In(loc)
+	if !workingToday {
+		anchor = anchor.AddDate(0, 0, -2)
+	}
+	return &domain.Schedule{Rotation: &domain.Rotation{
+		Name:       "Panama",
+		AnchorDate: anchor.Format("2006-01-02"),
+		Days:       []*string{&shift.ID, &shift.ID, nil, nil},
+		Timezone:   shift.Timezone,
+		Shifts:     map[string]*domain.Shift{shift.ID: shift},
+	}}
+}
+
+// middaySplitShift returns middayShift split into the given segments, such
+// as "07:00-11:00", with no grace period.
+func middaySplitShift(segments ...string) *domain.Shift {
+	shift := middayShift("00:00", 0, true)
+	shift.Name = "Split"
+	for _, segment := range segments {
+		start, end, _ := strings.Cut(segment, "-")
+		shift.Segments = append(shift.Segments, domain.ShiftSegment{StartTime: start, EndTime: end})
+	}
+	return shift
+}
+
 func TestCreateTask(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -97,6 +309,16 @@ func TestCreateTask(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "Geofencing Without Radius",
+			input: domain.Task{
+				Title:             "Site Visit",
+				GeofencingEnabled: true,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -104,7 +326,7 @@ func TestCreateTask(t *testing.T) {
 			mockRepo := new(MockAttendanceRepository)
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewAttendanceService(mockRepo)
+			svc := service.NewAttendanceService(mockRepo, new(MockOrgRepository), new(MockTransactionManager), service.RiskPolicy{})
 			handler := NewAttendanceHandler(svc)
 
 			body, _ := json.Marshal(tt.input)
@@ -124,13 +346,34 @@ func TestCreateTask(t *testing.T) {
 
 func TestCheckIn(t *testing.T) {
 	validOrgID := "123e4567-e89b-12d3-a456-426614174000"
+	validTaskID := "123e4567-e89b-12d3-a456-426614174001"
 	validUserID := "user-123"
+	geofencedTask := &domain.Task{
+		ID:                validTaskID,
+		OrgID:             validOrgID,
+		GeofencingEnabled: true,
+		Latitude:          41.7151,
+		Longitude:         44.8271,
+		RadiusMeters:      100,
+	}
+	headOfficeSite := &domain.Site{ID: "123e4567-e89b-12d3-a456-426614174003", OrgID: validOrgID, Name: "Head Office", Latitude: 41.7151, Longitude: 44.8271, RadiusMeters: 100}
+	branchSite := &domain.Site{ID: "123e4567-e89b-12d3-a456-426614174004", OrgID: validOrgID, Name: "Branch", Latitude: 41.6938, Longitude: 44.8015, RadiusMeters: 150}
+	warehouseSite := &domain.Site{ID: "123e4567-e89b-12d3-a456-426614174005", OrgID: validOrgID, Name: "Warehouse", Latitude: 41.7315, Longitude: 44.7620, Boundary: &domain.Boundary{
+		Type:        "Polygon",
+		Coordinates: json.RawMessage(`[[[44.7600,41.7300],[44.7640,41.7300],[44.7640,41.7330],[44.7600,41.7330],[44.7600,41.7300]]]`),
+	}}
+	accurateTo := func(meters float64) *float64 { return &meters }
+	checkpoint := &domain.Checkpoint{ID: "123e4567-e89b-12d3-a456-426614174002", OrgID: validOrgID, RotationSeconds: 30, Secret: []byte("checkpoint-secret")}
+	window := qrtoken.Window(time.Now(), 30*time.Second)
+	qrToken := qrtoken.Sign(checkpoint.Secret, checkpoint.ID, window)
 
 	tests := []struct {
 		name           string
 		input          domain.CheckInRequest
 		mockSetup      func(*MockAttendanceRepository)
+		orgSetup       func(*MockOrgRepository)
 		expectedStatus int
+		expectedResult string
 	}{
 		{
 			name: "Success - General CheckIn",
@@ -140,91 +383,1562 @@ func TestCheckIn(t *testing.T) {
 				Longitude:      20.0,
 			},
 			mockSetup: func(m *MockAttendanceRepository) {
-				m.On("GetLatestAttendance", mock.Anything, validUserID).Return(nil, nil)
-				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID).Return(&domain.Group{ID: "group-1"}, &domain.Shift{Name: "Morning"}, nil)
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: middayShift("12:00", 60, true)}, nil)
 				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
-					return a.Type == "GENERAL" && a.ShiftApplied == "Morning"
+					return a.Type == "GENERAL" && a.ShiftApplied == "Morning" && a.Status == domain.AttendanceStatusPresent
 				})).Return(nil)
 			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(&domain.Organization{ID: validOrgID, GeofenceMode: domain.GeofenceModeOff}, nil)
+			},
 			expectedStatus: http.StatusOK,
+			expectedResult: domain.AttendanceStatusPresent,
 		},
 		{
-			name: "Already Checked In",
+			name: "General CheckIn On Assigned Shift Without Group",
 			input: domain.CheckInRequest{
 				OrganizationID: validOrgID,
 				Latitude:       10.0,
 				Longitude:      20.0,
 			},
 			mockSetup: func(m *MockAttendanceRepository) {
-				m.On("GetLatestAttendance", mock.Anything, validUserID).Return(&domain.Attendance{CheckOutTime: nil}, nil)
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.MatchedBy(func(at time.Time) bool {
+					return time.Since(at) < time.Minute
+				})).Return(nil, &domain.Schedule{Shift: middayShift("12:00", 60, true)}, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.Type == "GENERAL" && a.ShiftApplied == "Morning" && a.ScheduledStart != nil
+				})).Return(nil)
 			},
-			expectedStatus: http.StatusBadRequest,
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(&domain.Organization{ID: validOrgID, GeofenceMode: domain.GeofenceModeOff}, nil)
+			},
+			expectedStatus: http.StatusOK,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := new(MockAttendanceRepository)
-			tt.mockSetup(mockRepo)
-
-			svc := service.NewAttendanceService(mockRepo)
-			handler := NewAttendanceHandler(svc)
-
-			body, _ := json.Marshal(tt.input)
-			req, _ := http.NewRequest("POST", "/attendance/check-in", bytes.NewBuffer(body))
-			ctx := context.WithValue(req.Context(), "user_id", validUserID)
-			req = req.WithContext(ctx)
-
-			rr := httptest.NewRecorder()
-			handler.CheckIn(rr, req)
-
-			assert.Equal(t, tt.expectedStatus, rr.Code)
-			mockRepo.AssertExpectations(t)
-		})
-	}
-}
-
-func TestCheckOut(t *testing.T) {
-	validUserID := "user-123"
-
-	tests := []struct {
-		name           string
-		mockSetup      func(*MockAttendanceRepository)
-		expectedStatus int
-	}{
 		{
-			name: "Success",
+			name: "General CheckIn On Rotation Day",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       10.0,
+				Longitude:      20.0,
+			},
 			mockSetup: func(m *MockAttendanceRepository) {
-				m.On("GetLatestAttendance", mock.Anything, validUserID).Return(&domain.Attendance{CheckOutTime: nil}, nil)
-				m.On("UpdateAttendance", mock.Anything, mock.Anything).Return(nil)
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, middayRotation(true), nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.ShiftApplied == "Morning" && a.Status == domain.AttendanceStatusPresent && a.ScheduledStart != nil
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(&domain.Organization{ID: validOrgID}, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "Not Checked In",
+			name: "General CheckIn On Rotation Day Off",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       10.0,
+				Longitude:      20.0,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, middayRotation(false), nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.ShiftApplied == "Panama" && a.Status == domain.AttendanceStatusNonWorkingDay && a.ScheduledStart == nil
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(&domain.Organization{ID: validOrgID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: domain.AttendanceStatusNonWorkingDay,
+		},
+		{
+			name: "General CheckIn Between Split Shift Segments",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       10.0,
+				Longitude:      20.0,
+			},
 			mockSetup: func(m *MockAttendanceRepository) {
-				m.On("GetLatestAttendance", mock.Anything, validUserID).Return(nil, nil)
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: middaySplitShift("07:00-11:00", "16:00-20:00")}, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.ShiftSegment == 2 && a.Status == domain.AttendanceStatusPresent && a.ScheduledStart.After(time.Now())
+				})).Return(nil)
 			},
-			expectedStatus: http.StatusBadRequest,
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(&domain.Organization{ID: validOrgID}, nil)
+			},
+			expectedStatus: http.StatusOK,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := new(MockAttendanceRepository)
-			tt.mockSetup(mockRepo)
-
-			svc := service.NewAttendanceService(mockRepo)
-			handler := NewAttendanceHandler(svc)
-
-			req, _ := http.NewRequest("POST", "/attendance/check-out", nil)
-			ctx := context.WithValue(req.Context(), "user_id", validUserID)
-			req = req.WithContext(ctx)
-
-			rr := httptest.NewRecorder()
-			handler.CheckOut(rr, req)
+		{
+			name: "Late For Second Split Shift Segment",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       10.0,
+				Longitude:      20.0,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: middaySplitShift("06:00-09:00", "12:00-16:00")}, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					// Late against the segment's 12:00 start, not the shift's 06:00
+					return a.ShiftSegment == 2 && a.Status == domain.AttendanceStatusLate && a.LateMinutes < 60
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(&domain.Organization{ID: validOrgID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Late General CheckIn",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       10.0,
+				Longitude:      20.0,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: middayShift("09:00", 15, true)}, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.Status == domain.AttendanceStatusLate && a.LateMinutes >= 180
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(&domain.Organization{ID: validOrgID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "General CheckIn On Non-Working Day",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       10.0,
+				Longitude:      20.0,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: middayShift("09:00", 15, false)}, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.Status == domain.AttendanceStatusNonWorkingDay
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(&domain.Organization{ID: validOrgID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "General CheckIn Outside Org Geofence - Warn",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.7251,
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.Flagged && a.FlagReason != "" && a.DistanceMeters != nil
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(geofencedOrg(domain.GeofenceModeWarn), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "General CheckIn Outside Org Geofence - Reject",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.7251,
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateCheckInViolation", mock.Anything, mock.MatchedBy(func(v *domain.CheckInViolation) bool {
+					return v.Kind == domain.ViolationKindGeofence && v.UserID == validUserID && v.DistanceMeters != nil
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "General CheckIn From Outside Allowed Networks - Reject",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.7151,
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateCheckInViolation", mock.Anything, mock.MatchedBy(func(v *domain.CheckInViolation) bool {
+					return v.Kind == domain.ViolationKindNetwork && v.ClientIP == "203.0.113.7"
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(networkOrg(domain.GeofenceModeReject, domain.GeofenceModeOff, domain.LocationRuleAll), nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "General CheckIn From Outside Allowed Networks - Warn",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.7151,
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.Flagged && a.ClientIP == "203.0.113.7"
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(networkOrg(domain.GeofenceModeWarn, domain.GeofenceModeOff, domain.LocationRuleAll), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "General CheckIn Inside Geofence Under Any Rule",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.7151,
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return !a.Flagged
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(networkOrg(domain.GeofenceModeReject, domain.GeofenceModeReject, domain.LocationRuleAny), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "General CheckIn Outside Geofence And Networks Under Any Rule",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.7251,
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateCheckInViolation", mock.Anything, mock.Anything).Return(nil).Twice()
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(networkOrg(domain.GeofenceModeReject, domain.GeofenceModeReject, domain.LocationRuleAny), nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "General CheckIn Recorded At Site Containing Location",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.6938,
+				Longitude:      44.8015,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.SiteID != nil && *a.SiteID == branchSite.ID && !a.Flagged
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+				m.On("ListSites", mock.Anything, validOrgID).Return([]*domain.Site{headOfficeSite, branchSite}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "General CheckIn Outside Every Site - Reject",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.6500,
+				Longitude:      44.8015,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateCheckInViolation", mock.Anything, mock.MatchedBy(func(v *domain.CheckInViolation) bool {
+					return v.Kind == domain.ViolationKindGeofence && *v.DistanceMeters > 4000
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+				m.On("ListSites", mock.Anything, validOrgID).Return([]*domain.Site{headOfficeSite, branchSite}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "General CheckIn Inside Site Boundary",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.7315,
+				Longitude:      44.7620,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return *a.SiteID == warehouseSite.ID && *a.DistanceMeters == 0 && !a.Flagged
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+				m.On("ListSites", mock.Anything, validOrgID).Return([]*domain.Site{branchSite, headOfficeSite, warehouseSite}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "General CheckIn Outside Site Boundary Within Accuracy",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.73318, // About 20m north of the boundary
+				Longitude:      44.7620,
+				Accuracy:       accurateTo(30),
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return *a.SiteID == warehouseSite.ID && *a.DistanceMeters > 15 && !a.Flagged
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+				m.On("ListSites", mock.Anything, validOrgID).Return([]*domain.Site{branchSite, headOfficeSite, warehouseSite}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "General CheckIn Outside Site Boundary Beyond Tolerance - Reject",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.73372, // About 80m north of the boundary
+				Longitude:      44.7620,
+				Accuracy:       accurateTo(500),
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateCheckInViolation", mock.Anything, mock.MatchedBy(func(v *domain.CheckInViolation) bool {
+					return v.Kind == domain.ViolationKindGeofence && *v.DistanceMeters > 75 && *v.DistanceMeters < 85
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+				m.On("ListSites", mock.Anything, validOrgID).Return([]*domain.Site{branchSite, headOfficeSite, warehouseSite}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "General CheckIn Away From Group Site - Warn",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       41.7151,
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1", SiteID: &branchSite.ID}, nil, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return *a.SiteID == branchSite.ID && a.Flagged && strings.Contains(a.FlagReason, "site Branch")
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(geofencedOrg(domain.GeofenceModeWarn), nil)
+				m.On("GetSiteByID", mock.Anything, branchSite.ID).Return(branchSite, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Already Checked In",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       10.0,
+				Longitude:      20.0,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return([]*domain.Attendance{{CheckOutTime: nil}}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Concurrent CheckIn",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				Latitude:       10.0,
+				Longitude:      20.0,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateAttendance", mock.Anything, mock.Anything).Return(&domain.DuplicateError{Field: "open session"})
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, validOrgID).Return(&domain.Organization{ID: validOrgID, GeofenceMode: domain.GeofenceModeOff}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "Success - QR CheckIn Without Coordinates",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				QRToken:        qrToken,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetCheckpointByID", mock.Anything, checkpoint.ID).Return(checkpoint, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("UseCheckpointToken", mock.Anything, checkpoint.ID, validUserID, mock.Anything).Return(nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.ProofType == domain.ProofTypeQR && *a.CheckpointID == checkpoint.ID && a.DistanceMeters == nil
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "QR Token Already Used",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				QRToken:        qrToken,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetCheckpointByID", mock.Anything, checkpoint.ID).Return(checkpoint, nil)
+				m.On("GetMemberGroup", mock.Anything, validOrgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("UseCheckpointToken", mock.Anything, checkpoint.ID, validUserID, mock.Anything).Return(&domain.DuplicateError{Field: "checkpoint token use"})
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "Expired QR Token",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				QRToken:        qrtoken.Sign(checkpoint.Secret, checkpoint.ID, window-2),
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetCheckpointByID", mock.Anything, checkpoint.ID).Return(checkpoint, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "QR Token With Wrong Signature",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				QRToken:        qrtoken.Sign([]byte("other-secret"), checkpoint.ID, window),
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetCheckpointByID", mock.Anything, checkpoint.ID).Return(checkpoint, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "QR Token From Another Organization",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				QRToken:        qrToken,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetCheckpointByID", mock.Anything, checkpoint.ID).Return(&domain.Checkpoint{ID: checkpoint.ID, OrgID: "org-2", RotationSeconds: 30, Secret: checkpoint.Secret}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Success - Task CheckIn Inside Geofence",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				TaskID:         &validTaskID,
+				Latitude:       41.7151,
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetTaskByID", mock.Anything, validTaskID).Return(geofencedTask, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.Type == "TASK" && a.DistanceMeters != nil && *a.DistanceMeters < 100
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Task CheckIn Outside Geofence",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				TaskID:         &validTaskID,
+				Latitude:       41.7251, // ~1.1km north of the task location
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetTaskByID", mock.Anything, validTaskID).Return(geofencedTask, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "Task CheckIn For Another Organization's Task",
+			input: domain.CheckInRequest{
+				OrganizationID: validOrgID,
+				TaskID:         &validTaskID,
+				Latitude:       41.7151,
+				Longitude:      44.8271,
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, validOrgID).Return(nil, nil)
+				m.On("GetTaskByID", mock.Anything, validTaskID).Return(&domain.Task{ID: validTaskID, OrgID: "other-org"}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			if tt.orgSetup != nil {
+				tt.orgSetup(mockOrgRepo)
+			}
+			// Organizations have no sites unless the case sets them up
+			mockOrgRepo.On("ListSites", mock.Anything, validOrgID).Return(nil, nil).Maybe()
+
+			svc := service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewAttendanceHandler(svc)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/attendance/check-in", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", validUserID)
+			ctx = context.WithValue(ctx, "client_ip", "203.0.113.7")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler.CheckIn(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedResult != "" {
+				var resp map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedResult, resp["attendance_status"])
+			}
+			mockRepo.AssertExpectations(t)
+			mockOrgRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestCheckInShiftAttribution checks in at fixed times, as offline sync does,
+// to cover overnight shifts and the Europe/Berlin DST transition days.
+func TestCheckInShiftAttribution(t *testing.T) {
+	orgID := "123e4567-e89b-12d3-a456-426614174000"
+	userID := "user-123"
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	shift := func(start, end string, allowedLateMinutes int) *domain.Shift {
+		return &domain.Shift{
+			Name:               "Shift",
+			StartTime:          start,
+			EndTime:            end,
+			Timezone:           "Europe/Berlin",
+			AllowedLateMinutes: allowedLateMinutes,
+			WorkingDays:        []string{"MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		shift             *domain.Shift
+		checkIn           time.Time
+		expectedShiftDate string
+		expectedStart     time.Time
+		expectedStatus    string
+		expectedLate      int
+	}{
+		{
+			name:              "Overnight Shift Late After Midnight",
+			shift:             shift("22:00", "06:00", 5),
+			checkIn:           time.Date(2026, 3, 11, 2, 0, 0, 0, berlin),
+			expectedShiftDate: "2026-03-10",
+			expectedStart:     time.Date(2026, 3, 10, 22, 0, 0, 0, berlin),
+			expectedStatus:    domain.AttendanceStatusLate,
+			expectedLate:      240,
+		},
+		{
+			name:              "Overnight Shift Within Grace After Midnight",
+			shift:             shift("22:00", "06:00", 300),
+			checkIn:           time.Date(2026, 3, 11, 2, 0, 0, 0, berlin),
+			expectedShiftDate: "2026-03-10",
+			expectedStart:     time.Date(2026, 3, 10, 22, 0, 0, 0, berlin),
+			expectedStatus:    domain.AttendanceStatusPresent,
+		},
+		{
+			// Clocks go forward at 02:00, so 09:00 is 07:00 UTC instead of 08:00
+			name:              "Late On Spring Forward Day",
+			shift:             shift("09:00", "17:00", 5),
+			checkIn:           time.Date(2026, 3, 29, 7, 10, 0, 0, time.UTC),
+			expectedShiftDate: "2026-03-29",
+			expectedStart:     time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC),
+			expectedStatus:    domain.AttendanceStatusLate,
+			expectedLate:      10,
+		},
+		{
+			name:              "On Time On Spring Forward Day",
+			shift:             shift("09:00", "17:00", 5),
+			checkIn:           time.Date(2026, 3, 29, 7, 3, 0, 0, time.UTC),
+			expectedShiftDate: "2026-03-29",
+			expectedStart:     time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC),
+			expectedStatus:    domain.AttendanceStatusPresent,
+		},
+		{
+			// Clocks go back at 03:00, so 09:00 is 08:00 UTC instead of 07:00
+			name:              "Late On Fall Back Day",
+			shift:             shift("09:00", "17:00", 5),
+			checkIn:           time.Date(2026, 10, 25, 8, 10, 0, 0, time.UTC),
+			expectedShiftDate: "2026-10-25",
+			expectedStart:     time.Date(2026, 10, 25, 8, 0, 0, 0, time.UTC),
+			expectedStatus:    domain.AttendanceStatusLate,
+			expectedLate:      10,
+		},
+		{
+			name:              "On Time On Fall Back Day",
+			shift:             shift("09:00", "17:00", 5),
+			checkIn:           time.Date(2026, 10, 25, 8, 3, 0, 0, time.UTC),
+			expectedShiftDate: "2026-10-25",
+			expectedStart:     time.Date(2026, 10, 25, 8, 0, 0, 0, time.UTC),
+			expectedStatus:    domain.AttendanceStatusPresent,
+		},
+		{
+			// 02:30 CET, after the clocks went back, is 5h30m after 22:00 CEST
+			name:              "Overnight Shift Across Fall Back Night",
+			shift:             shift("22:00", "06:00", 5),
+			checkIn:           time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC),
+			expectedShiftDate: "2026-10-24",
+			expectedStart:     time.Date(2026, 10, 24, 20, 0, 0, 0, time.UTC),
+			expectedStatus:    domain.AttendanceStatusLate,
+			expectedLate:      330,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			mockOrgRepo := new(MockOrgRepository)
+			mockRepo.On("ListOpenAttendance", mock.Anything, userID, orgID).Return(nil, nil)
+			mockRepo.On("GetMemberGroup", mock.Anything, orgID, userID, tt.checkIn).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: tt.shift}, nil)
+			mockRepo.On("CreateAttendance", mock.Anything, mock.Anything).Return(nil)
+			mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID).Return(&domain.Organization{ID: orgID, GeofenceMode: domain.GeofenceModeOff}, nil)
+			mockOrgRepo.On("ListSites", mock.Anything, orgID).Return(nil, nil)
+
+			svc := service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			att, err := svc.CheckIn(context.Background(), userID, orgID, &domain.Attendance{CheckInTime: tt.checkIn}, "")
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectedShiftDate, att.ShiftDate)
+				assert.Equal(t, tt.expectedStatus, att.Status)
+				assert.Equal(t, tt.expectedLate, att.LateMinutes)
+				if assert.NotNil(t, att.ScheduledStart) {
+					assert.True(t, tt.expectedStart.Equal(*att.ScheduledStart), "scheduled start %s, want %s", att.ScheduledStart, tt.expectedStart)
+				}
+			}
+			mockRepo.AssertExpectations(t)
+			mockOrgRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCheckInRisk(t *testing.T) {
+	orgID := "123e4567-e89b-12d3-a456-426614174000"
+	userID := "user-123"
+	lat, long := 41.7151, 44.8271
+	accuracy := func(m float64) *float64 { return &m }
+	policy := service.RiskPolicy{MaxAccuracyMeters: 100, MaxTravelSpeedKmh: 250, CoordinateReuseWindow: 30 * 24 * time.Hour}
+
+	tests := []struct {
+		name            string
+		input           domain.CheckInRequest
+		reused          bool
+		lastPunch       *domain.PunchLocation
+		expectedScore   int
+		expectedReasons []string
+	}{
+		{
+			name:          "Clean",
+			input:         domain.CheckInRequest{OrganizationID: orgID, Latitude: lat, Longitude: long, Accuracy: accuracy(12), Provider: "gps"},
+			lastPunch:     &domain.PunchLocation{Time: time.Now().Add(-time.Hour), Latitude: 41.72, Longitude: 44.83},
+			expectedScore: 0,
+		},
+		{
+			name:            "Mock Location With Poor Accuracy",
+			input:           domain.CheckInRequest{OrganizationID: orgID, Latitude: lat, Longitude: long, Accuracy: accuracy(500), MockLocation: true},
+			expectedScore:   80,
+			expectedReasons: []string{domain.RiskMockLocation, domain.RiskPoorAccuracy},
+		},
+		{
+			name:            "Coordinates Reused By Another Member",
+			input:           domain.CheckInRequest{OrganizationID: orgID, Latitude: lat, Longitude: long},
+			reused:          true,
+			expectedScore:   40,
+			expectedReasons: []string{domain.RiskReusedCoordinates},
+		},
+		{
+			// Batumi is about 300 km from Tbilisi
+			name:            "Impossible Travel",
+			input:           domain.CheckInRequest{OrganizationID: orgID, Latitude: lat, Longitude: long},
+			lastPunch:       &domain.PunchLocation{Time: time.Now().Add(-30 * time.Minute), Latitude: 41.6168, Longitude: 41.6367},
+			expectedScore:   50,
+			expectedReasons: []string{domain.RiskImpossibleTravel},
+		},
+		{
+			name:            "Score Is Capped",
+			input:           domain.CheckInRequest{OrganizationID: orgID, Latitude: lat, Longitude: long, Accuracy: accuracy(500), MockLocation: true},
+			reused:          true,
+			lastPunch:       &domain.PunchLocation{Time: time.Now().Add(-30 * time.Minute), Latitude: 41.6168, Longitude: 41.6367},
+			expectedScore:   100,
+			expectedReasons: []string{domain.RiskMockLocation, domain.RiskPoorAccuracy, domain.RiskReusedCoordinates, domain.RiskImpossibleTravel},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			mockRepo.On("ListOpenAttendance", mock.Anything, userID, orgID).Return(nil, nil)
+			mockRepo.On("GetMemberGroup", mock.Anything, orgID, userID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+			mockRepo.On("CoordinatesUsedByOthers", mock.Anything, userID, lat, long, mock.AnythingOfType("time.Time")).Return(tt.reused, nil)
+			if tt.lastPunch != nil {
+				mockRepo.On("GetLastPunchLocation", mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(tt.lastPunch, nil)
+			} else {
+				mockRepo.On("GetLastPunchLocation", mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(nil, nil)
+			}
+			mockRepo.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(a *domain.Attendance) bool {
+				return a.RiskScore == tt.expectedScore && a.MockLocation == tt.input.MockLocation
+			})).Return(nil)
+			mockOrgRepo := new(MockOrgRepository)
+			mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID).Return(&domain.Organization{ID: orgID, GeofenceMode: domain.GeofenceModeOff}, nil)
+			mockOrgRepo.On("ListSites", mock.Anything, orgID).Return(nil, nil)
+
+			handler := NewAttendanceHandler(service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), policy))
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/attendance/check-in", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", userID)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler.CheckIn(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp struct {
+				RiskScore   int      `json:"risk_score"`
+				RiskReasons []string `json:"risk_reasons"`
+			}
+			assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedScore, resp.RiskScore)
+			assert.Equal(t, tt.expectedReasons, resp.RiskReasons)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCheckOut(t *testing.T) {
+	validUserID := "user-123"
+	orgID := "123e4567-e89b-12d3-a456-426614174000"
+	scheduledEnd := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name           string
+		input          string
+		mockSetup      func(*MockAttendanceRepository)
+		orgSetup       func(*MockOrgRepository)
+		expectedStatus int
+		expectedResult string
+	}{
+		{
+			name: "Success",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{CheckOutTime: nil}}, nil)
+				m.On("EndBreak", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+				m.On("UpdateAttendance", mock.Anything, mock.Anything).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, "").Return(&domain.Organization{GeofenceMode: domain.GeofenceModeOff}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Failed Update Rolls Back Break End",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1"}}, nil)
+				m.On("EndBreak", mock.MatchedBy(func(ctx context.Context) bool {
+					return ctx.Value(inTxKey{}) != nil
+				}), "att-1", mock.Anything).Return(&domain.AttendanceBreak{ID: "brk-1"}, nil)
+				m.On("UpdateAttendance", mock.MatchedBy(func(ctx context.Context) bool {
+					return ctx.Value(inTxKey{}) != nil
+				}), mock.Anything).Return(fmt.Errorf("connection reset"))
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, "").Return(&domain.Organization{GeofenceMode: domain.GeofenceModeOff}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Early Leave",
+			input: `{"latitude": 41.7152, "longitude": 44.8271, "note": "Doctor's appointment"}`,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1", OrgID: orgID, ScheduledEnd: &scheduledEnd}}, nil)
+				m.On("GetMemberGroup", mock.Anything, orgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: &domain.Shift{AllowedEarlyLeaveMinutes: 15}}, nil)
+				m.On("EndBreak", mock.Anything, "att-1", mock.Anything).Return(nil, nil)
+				m.On("UpdateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+					return att.CheckOutStatus == domain.CheckOutStatusEarlyLeave && att.EarlyLeaveMinutes >= 59 &&
+						*att.CheckOutLat == 41.7152 && att.CheckOutNote == "Doctor's appointment" && !att.Flagged
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, orgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: domain.CheckOutStatusEarlyLeave,
+		},
+		{
+			name:  "Outside Geofence - Reject",
+			input: `{"latitude": 41.7251, "longitude": 44.8271}`,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1", OrgID: orgID}}, nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, orgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "Missing Location - Reject",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1", OrgID: orgID}}, nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, orgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Outside Geofence - Warn",
+			input: `{"latitude": 41.7251, "longitude": 44.8271}`,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1", OrgID: orgID}}, nil)
+				m.On("EndBreak", mock.Anything, "att-1", mock.Anything).Return(nil, nil)
+				m.On("UpdateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+					return att.Flagged && *att.CheckOutDistanceMeters > 1000
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, orgID).Return(geofencedOrg(domain.GeofenceModeWarn), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Latitude Without Longitude",
+			input: `{"latitude": 41.7152}`,
+			mockSetup: func(m *MockAttendanceRepository) {
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Not Checked In",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return(nil, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Open In Several Organizations",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1", OrgID: orgID}, {ID: "att-2", OrgID: "org-2"}}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Scoped To Organization",
+			input: `{"organization_id": "` + orgID + `"}`,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, orgID).Return([]*domain.Attendance{{ID: "att-1", OrgID: orgID}}, nil)
+				m.On("EndBreak", mock.Anything, "att-1", mock.Anything).Return(nil, nil)
+				m.On("UpdateAttendance", mock.Anything, mock.Anything).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, orgID).Return(&domain.Organization{GeofenceMode: domain.GeofenceModeOff}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "By Attendance ID",
+			input: `{"attendance_id": "123e4567-e89b-12d3-a456-426614174010"}`,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, "123e4567-e89b-12d3-a456-426614174010").Return(&domain.Attendance{ID: "att-1", UserID: validUserID, OrgID: orgID}, nil)
+				m.On("EndBreak", mock.Anything, "att-1", mock.Anything).Return(nil, nil)
+				m.On("UpdateAttendance", mock.Anything, mock.Anything).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, orgID).Return(&domain.Organization{GeofenceMode: domain.GeofenceModeOff}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Another User's Session",
+			input: `{"attendance_id": "123e4567-e89b-12d3-a456-426614174010"}`,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, "123e4567-e89b-12d3-a456-426614174010").Return(&domain.Attendance{ID: "att-1", UserID: "user-456", OrgID: orgID}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			if tt.orgSetup != nil {
+				tt.orgSetup(mockOrgRepo)
+			}
+
+			svc := service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewAttendanceHandler(svc)
+
+			req, _ := http.NewRequest("POST", "/attendance/check-out", nil)
+			if tt.input != "" {
+				req, _ = http.NewRequest("POST", "/attendance/check-out", strings.NewReader(tt.input))
+			}
+			ctx := context.WithValue(req.Context(), "user_id", validUserID)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler.CheckOut(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedResult != "" {
+				var resp map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedResult, resp["check_out_status"])
+			}
+			mockRepo.AssertExpectations(t)
+			mockOrgRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListMyAttendance(t *testing.T) {
+	validUserID := "user-123"
+	checkIn := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	checkOut := checkIn.Add(8*time.Hour + 30*time.Minute)
+	records := []*domain.Attendance{
+		{ID: "123e4567-e89b-12d3-a456-426614174023", CheckInTime: checkIn, CheckOutTime: &checkOut, UnpaidBreakMinutes: 30},
+		{ID: "123e4567-e89b-12d3-a456-426614174022", CheckInTime: checkIn.AddDate(0, 0, -1)},
+		{ID: "123e4567-e89b-12d3-a456-426614174021", CheckInTime: checkIn.AddDate(0, 0, -2)},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockAttendanceRepository)
+		expectedStatus int
+		expectedItems  int
+		expectCursor   bool
+	}{
+		{
+			name:  "Success - First Page",
+			query: "?from=2026-03-01&to=2026-03-02&type=GENERAL&limit=2",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListAttendance", mock.Anything, mock.MatchedBy(func(f domain.AttendanceFilter) bool {
+					return f.UserID == validUserID && f.Type == "GENERAL" && f.Limit == 3 &&
+						f.From.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) &&
+						f.To.Equal(time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC))
+				})).Return(records, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedItems:  2,
+			expectCursor:   true,
+		},
+		{
+			name:  "Success - Last Page",
+			query: "?cursor=" + "MjAyNi0wMy0wMlQwOTowMDowMFp8MTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDIz",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListAttendance", mock.Anything, mock.MatchedBy(func(f domain.AttendanceFilter) bool {
+					return f.CursorID == "123e4567-e89b-12d3-a456-426614174023" && f.CursorTime.Equal(checkIn)
+				})).Return(records[1:], nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedItems:  2,
+		},
+		{
+			name:           "Invalid Status",
+			query:          "?status=HOLIDAY",
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Cursor",
+			query:          "?cursor=not-a-cursor",
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Cursor With Invalid ID",
+			query:          "?cursor=" + "MjAyNi0wMy0wMlQwOTowMDowMFp8YXR0LTM",
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewAttendanceService(mockRepo, new(MockOrgRepository), new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewAttendanceHandler(svc)
+
+			req, _ := http.NewRequest("GET", "/me/attendance"+tt.query, nil)
+			ctx := context.WithValue(req.Context(), "user_id", validUserID)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler.ListMyAttendance(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var page domain.AttendancePage
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+				assert.Len(t, page.Items, tt.expectedItems)
+				assert.Equal(t, tt.expectCursor, page.NextCursor != "")
+				for _, item := range page.Items {
+					if item.CheckOutTime == nil {
+						assert.Nil(t, item.WorkedMinutes)
+					} else if assert.NotNil(t, item.WorkedMinutes) {
+						assert.Equal(t, 480, *item.WorkedMinutes) // Unpaid break subtracted
+					}
+				}
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListTeamAttendance(t *testing.T) {
+	orgID := "org-1"
+	groupID := "123e4567-e89b-12d3-a456-426614174002"
+
+	tests := []struct {
+		name           string
+		requesterRole  string
+		query          string
+		mockSetup      func(*MockAttendanceRepository)
+		expectedStatus int
+	}{
+		{
+			name:          "Success - Manager Filters Open Sessions In Group",
+			requesterRole: "MANAGER",
+			query:         "?group_id=" + groupID + "&open=true",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOrgAttendance", mock.Anything, mock.MatchedBy(func(f domain.AttendanceFilter) bool {
+					return f.OrgID == orgID && f.GroupID == groupID && f.Open != nil && *f.Open
+				})).Return([]*domain.AttendanceDetail{
+					{Attendance: domain.Attendance{ID: "att-1", UserID: "user-2"}, User: domain.User{ID: "user-2", FullName: "Jane Doe"}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Forbidden - Employee",
+			requesterRole:  "EMPLOYEE",
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Invalid Open Flag",
+			requesterRole:  "OWNER",
+			query:          "?open=maybe",
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Suspicious Punches",
+			requesterRole: "OWNER",
+			query:         "?min_risk_score=50",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOrgAttendance", mock.Anything, mock.MatchedBy(func(f domain.AttendanceFilter) bool {
+					return f.MinRiskScore == 50
+				})).Return([]*domain.AttendanceDetail{
+					{Attendance: domain.Attendance{ID: "att-1", UserID: "user-2", RiskScore: 60, RiskReasons: []string{domain.RiskMockLocation}}, User: domain.User{ID: "user-2", FullName: "Jane Doe"}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Risk Score",
+			requesterRole:  "OWNER",
+			query:          "?min_risk_score=101",
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			mockOrgRepo.On("GetMember", mock.Anything, orgID, "user-123").Return(&domain.OrganizationMember{Role: tt.requesterRole}, nil).Maybe()
+
+			svc := service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewAttendanceHandler(svc)
+
+			r := chi.NewRouter()
+			r.Get("/organizations/{org_id}/attendance", handler.ListTeamAttendance)
+
+			req, _ := http.NewRequest("GET", "/organizations/"+orgID+"/attendance"+tt.query, nil)
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var page domain.TeamAttendancePage
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+				assert.Equal(t, "Jane Doe", page.Items[0].User.FullName)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateManualAttendance(t *testing.T) {
+	memberID := "123e4567-e89b-12d3-a456-426614174003"
+	dayShift := &domain.Shift{
+		Name:               "Day",
+		StartTime:          "09:00",
+		EndTime:            "17:00",
+		Timezone:           "UTC",
+		AllowedLateMinutes: 5,
+		WorkingDays:        []string{"MON", "TUE", "WED", "THU", "FRI"},
+	}
+
+	tests := []struct {
+		name           string
+		requesterRole  string
+		input          domain.ManualAttendanceRequest
+		mockSetup      func(*MockAttendanceRepository)
+		expectedStatus int
+	}{
+		{
+			name:          "Success - Evaluated Against Shift",
+			requesterRole: "MANAGER",
+			input: domain.ManualAttendanceRequest{
+				UserID:       memberID,
+				CheckInTime:  time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC),
+				CheckOutTime: time.Date(2024, 3, 5, 17, 45, 0, 0, time.UTC),
+				Note:         "Phone battery died",
+			},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetMemberGroup", mock.Anything, "org-1", memberID, mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: dayShift}, nil)
+				m.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+					return att.Source == domain.AttendanceSourceManual && *att.RecordedBy == "user-123" &&
+						att.Status == domain.AttendanceStatusLate && att.LateMinutes == 30 &&
+						att.ShiftDate == "2024-03-05" && att.OvertimeMinutes == 45
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:          "Forbidden - Employee",
+			requesterRole: "EMPLOYEE",
+			input: domain.ManualAttendanceRequest{
+				UserID:       memberID,
+				CheckInTime:  time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+				CheckOutTime: time.Date(2024, 3, 5, 17, 0, 0, 0, time.UTC),
+				Note:         "Phone battery died",
+			},
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "Check-out Before Check-in",
+			requesterRole: "OWNER",
+			input: domain.ManualAttendanceRequest{
+				UserID:       memberID,
+				CheckInTime:  time.Date(2024, 3, 5, 17, 0, 0, 0, time.UTC),
+				CheckOutTime: time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+				Note:         "Phone battery died",
+			},
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Missing Note",
+			requesterRole: "OWNER",
+			input: domain.ManualAttendanceRequest{
+				UserID:       memberID,
+				CheckInTime:  time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+				CheckOutTime: time.Date(2024, 3, 5, 17, 0, 0, 0, time.UTC),
+			},
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			mockOrgRepo.On("GetMember", mock.Anything, "org-1", "user-123").Return(&domain.OrganizationMember{Role: tt.requesterRole}, nil).Maybe()
+			mockOrgRepo.On("GetMember", mock.Anything, "org-1", memberID).Return(&domain.OrganizationMember{Role: "EMPLOYEE"}, nil).Maybe()
+
+			svc := service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewAttendanceHandler(svc)
+
+			r := chi.NewRouter()
+			r.Post("/organizations/{org_id}/attendance", handler.CreateManualAttendance)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/organizations/org-1/attendance", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEditAttendance(t *testing.T) {
+	attendanceID := "123e4567-e89b-12d3-a456-426614174004"
+	scheduledStart := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+	scheduledEnd := time.Date(2024, 3, 5, 17, 0, 0, 0, time.UTC)
+
+	mockRepo := new(MockAttendanceRepository)
+	mockRepo.On("GetAttendanceByID", mock.Anything, attendanceID).Return(&domain.Attendance{
+		ID:             attendanceID,
+		UserID:         "user-456",
+		OrgID:          "org-1",
+		CheckInTime:    scheduledStart,
+		Status:         domain.AttendanceStatusAbsent,
+		Source:         domain.AttendanceSourceDevice,
+		ScheduledStart: &scheduledStart,
+		ScheduledEnd:   &scheduledEnd,
+	}, nil)
+	mockRepo.On("GetMemberGroup", mock.Anything, "org-1", "user-456", mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: &domain.Shift{AllowedLateMinutes: 5}}, nil)
+	mockRepo.On("UpdateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+		return att.Status == domain.AttendanceStatusPresent && att.Source == domain.AttendanceSourceManual && *att.RecordedBy == "user-123"
+	})).Return(nil)
+	mockOrgRepo := new(MockOrgRepository)
+	mockOrgRepo.On("GetMember", mock.Anything, "org-1", "user-123").Return(&domain.OrganizationMember{Role: "OWNER"}, nil)
+
+	svc := service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+	handler := NewAttendanceHandler(svc)
+
+	r := chi.NewRouter()
+	r.Put("/organizations/{org_id}/attendance/{attendance_id}", handler.EditAttendance)
+
+	body, _ := json.Marshal(domain.AttendanceEditRequest{
+		CheckInTime:  scheduledStart.Add(3 * time.Minute),
+		CheckOutTime: scheduledEnd,
+		Note:         "Onsite incident, badge reader offline",
+	})
+	req, _ := http.NewRequest("PUT", "/organizations/org-1/attendance/"+attendanceID, bytes.NewBuffer(body))
+	ctx := context.WithValue(req.Context(), "user_id", "user-123")
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var att domain.Attendance
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &att))
+	assert.Equal(t, 477, *att.WorkedMinutes)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStartBreak(t *testing.T) {
+	validUserID := "user-123"
+	session := &domain.Attendance{ID: "att-1", OrgID: "org-1"}
+	typedShift := &domain.Shift{BreakTypes: []domain.BreakType{{Name: "LUNCH"}, {Name: "COFFEE", Paid: true}}}
+
+	tests := []struct {
+		name           string
+		input          string
+		mockSetup      func(*MockAttendanceRepository)
+		expectedStatus int
+	}{
+		{
+			name:  "Success - Paid Break Type",
+			input: `{"type": "COFFEE"}`,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{session}, nil)
+				m.On("GetOpenBreak", mock.Anything, "att-1").Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, "org-1", validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: typedShift}, nil)
+				m.On("CreateBreak", mock.Anything, mock.MatchedBy(func(b *domain.AttendanceBreak) bool {
+					return b.AttendanceID == "att-1" && b.Type == "COFFEE" && b.Paid
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Success - Default Unpaid Break",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{session}, nil)
+				m.On("GetOpenBreak", mock.Anything, "att-1").Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, "org-1", validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				m.On("CreateBreak", mock.Anything, mock.MatchedBy(func(b *domain.AttendanceBreak) bool {
+					return b.Type == domain.DefaultBreakType && !b.Paid
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:  "Unknown Break Type",
+			input: `{"type": "NAP"}`,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{session}, nil)
+				m.On("GetOpenBreak", mock.Anything, "att-1").Return(nil, nil)
+				m.On("GetMemberGroup", mock.Anything, "org-1", validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: typedShift}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Already On Break",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{session}, nil)
+				m.On("GetOpenBreak", mock.Anything, "att-1").Return(&domain.AttendanceBreak{ID: "brk-1"}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "Not Checked In",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return(nil, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewAttendanceService(mockRepo, new(MockOrgRepository), new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewAttendanceHandler(svc)
+
+			req, _ := http.NewRequest("POST", "/attendance/breaks/start", strings.NewReader(tt.input))
+			ctx := context.WithValue(req.Context(), "user_id", validUserID)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler.StartBreak(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEndBreak(t *testing.T) {
+	validUserID := "user-123"
+	started := time.Now().Add(-30 * time.Minute)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(*MockAttendanceRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1"}}, nil)
+				m.On("EndBreak", mock.Anything, "att-1", mock.Anything).Return(&domain.AttendanceBreak{ID: "brk-1", StartTime: started}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Not On Break",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1"}}, nil)
+				m.On("EndBreak", mock.Anything, "att-1", mock.Anything).Return(nil, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewAttendanceService(mockRepo, new(MockOrgRepository), new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewAttendanceHandler(svc)
+
+			req, _ := http.NewRequest("POST", "/attendance/breaks/end", nil)
+			ctx := context.WithValue(req.Context(), "user_id", validUserID)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler.EndBreak(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateCheckpoint(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		input          map[string]interface{}
+		mockSetup      func(*MockAttendanceRepository)
+		expectedStatus int
+	}{
+		{
+			name:  "Success - Default Rotation",
+			role:  "MANAGER",
+			input: map[string]interface{}{"name": "Main entrance"},
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("CreateCheckpoint", mock.Anything, mock.MatchedBy(func(c *domain.Checkpoint) bool {
+					return c.OrgID == "org-1" && c.RotationSeconds == domain.DefaultCheckpointRotationSeconds && len(c.Secret) == 32
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Rotation Too Short",
+			role:           "OWNER",
+			input:          map[string]interface{}{"name": "Main entrance", "rotation_seconds": 5},
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Forbidden - Employee",
+			role:           "EMPLOYEE",
+			input:          map[string]interface{}{"name": "Main entrance"},
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			mockOrgRepo.On("GetMember", mock.Anything, "org-1", "user-123").Return(&domain.OrganizationMember{Role: tt.role}, nil)
+
+			svc := service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewAttendanceHandler(svc)
+
+			r := chi.NewRouter()
+			r.Post("/organizations/{org_id}/checkpoints", handler.CreateCheckpoint)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/organizations/org-1/checkpoints", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if rr.Code == http.StatusCreated {
+				assert.NotContains(t, rr.Body.String(), "secret")
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetCheckpointToken(t *testing.T) {
+	checkpointID := "123e4567-e89b-12d3-a456-426614174002"
+	checkpoint := &domain.Checkpoint{ID: checkpointID, OrgID: "org-1", RotationSeconds: 30, Secret: []byte("checkpoint-secret")}
+
+	tests := []struct {
+		name           string
+		role           string
+		mockSetup      func(*MockAttendanceRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			role: "MANAGER",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetCheckpointByID", mock.Anything, checkpointID).Return(checkpoint, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Other Organization",
+			role: "OWNER",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetCheckpointByID", mock.Anything, checkpointID).Return(&domain.Checkpoint{ID: checkpointID, OrgID: "org-2"}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Forbidden - Employee",
+			role:           "EMPLOYEE",
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			mockOrgRepo.On("GetMember", mock.Anything, "org-1", "user-123").Return(&domain.OrganizationMember{Role: tt.role}, nil)
+
+			svc := service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewAttendanceHandler(svc)
+
+			r := chi.NewRouter()
+			r.Get("/organizations/{org_id}/checkpoints/{checkpoint_id}/token", handler.GetCheckpointToken)
+
+			req, _ := http.NewRequest("GET", "/organizations/org-1/checkpoints/"+checkpointID+"/token", nil)
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var token domain.CheckpointToken
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &token))
+				assert.True(t, qrtoken.Verify(checkpoint.Secret, token.Token))
+				assert.True(t, token.ExpiresAt.After(time.Now()))
+			}
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+	"github.com/syst3mctl/check-in-api/internal/pkg/response"
+	"github.com/syst3mctl/check-in-api/internal/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CorrectionHandler struct {
+	svc *service.CorrectionService
+}
+
+func NewCorrectionHandler(svc *service.CorrectionService) *CorrectionHandler {
+	return &CorrectionHandler{svc: svc}
+}
+
+// SubmitCorrection godoc
+// @Summary Request an attendance correction
+// @Description Ask a manager to change the check-in and/or check-out time of one of your attendance records. A missed shift needs both times.
+// @Tags Correction
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param attendance_id path string true "Attendance ID"
+// @Param request body domain.CorrectionRequest true "Correction Request"
+// @Param Idempotency-Key header string false "Key for safely retrying the request"
+// @Success 201 {object} domain.AttendanceCorrection
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 404 {object} domain.ErrorResponse "attendance not found"
+// @Failure 409 {object} domain.ErrorResponse "a correction is already pending"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /attendance/{attendance_id}/corrections [post]
+func (h *CorrectionHandler) SubmitCorrection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+	attendanceID := chi.URLParam(r, "attendance_id")
+	if !validator.IsValid(attendanceID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "attendance not found")
+		return
+	}
+
+	var req domain.CorrectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	correction, err := h.svc.SubmitCorrection(r.Context(), userID, attendanceID, &req)
+	if err != nil {
+		var dupErr *domain.DuplicateError
+		switch {
+		case errors.As(err, &dupErr):
+			response.WriteError(w, http.StatusConflict, dupErr.Error())
+		case err.Error() == "attendance not found":
+			response.WriteError(w, http.StatusNotFound, err.Error())
+		default:
+			response.WriteError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, correction)
+}
+
+// ListCorrections godoc
+// @Summary List correction requests
+// @Description List the organization's attendance correction requests, newest first (Owner/Manager only)
+// @Tags Correction
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param status query string false "Correction status" Enums(PENDING, APPROVED, REJECTED)
+// @Param user_id query string false "Only this member"
+// @Success 200 {array} domain.AttendanceCorrection
+// @Failure 400 {object} domain.ErrorResponse "invalid query parameters"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/corrections [get]
+func (h *CorrectionHandler) ListCorrections(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+	query := r.URL.Query()
+
+	filter := domain.CorrectionFilter{
+		Status: query.Get("status"),
+		UserID: query.Get("user_id"),
+	}
+	switch filter.Status {
+	case "", domain.CorrectionStatusPending, domain.CorrectionStatusApproved, domain.CorrectionStatusRejected:
+	default:
+		response.WriteError(w, http.StatusBadRequest, "status must be one of: PENDING, APPROVED, REJECTED")
+		return
+	}
+	if filter.UserID != "" && !validator.IsValid(filter.UserID, "uuid") {
+		response.WriteError(w, http.StatusBadRequest, "user_id must be a valid UUID")
+		return
+	}
+
+	corrections, err := h.svc.ListCorrections(r.Context(), userID, orgID, filter)
+	if err != nil {
+		if err.Error() == "unauthorized" {
+			response.WriteError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, corrections)
+}
+
+// ApproveCorrection godoc
+// @Summary Approve a correction request
+// @Description Apply the requested times to the attendance record, keeping the original values on the correction (Owner/Manager only)
+// @Tags Correction
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param correction_id path string true "Correction ID"
+// @Param request body domain.CorrectionReviewRequest false "Review note"
+// @Success 200 {object} domain.AttendanceCorrection
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or the correction no longer applies"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "correction not found"
+// @Failure 409 {object} domain.ErrorResponse "correction already reviewed"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/corrections/{correction_id}/approve [post]
+func (h *CorrectionHandler) ApproveCorrection(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.svc.ApproveCorrection)
+}
+
+// RejectCorrection godoc
+// @Summary Reject a correction request
+// @Description Close a correction request without changing the attendance record (Owner/Manager only)
+// @Tags Correction
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param correction_id path string true "Correction ID"
+// @Param request body domain.CorrectionReviewRequest false "Review note"
+// @Success 200 {object} domain.AttendanceCorrection
+// @Failure 400 {object} domain.ErrorResponse "invalid request body"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "correction not found"
+// @Failure 409 {object} domain.ErrorResponse "correction already reviewed"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/corrections/{correction_id}/reject [post]
+func (h *CorrectionHandler) RejectCorrection(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.svc.RejectCorrection)
+}
+
+type reviewFunc func(ctx context.Context, reviewerUserID, orgID, correctionID, note string) (*domain.AttendanceCorrection, error)
+
+func (h *CorrectionHandler) review(w http.ResponseWriter, r *http.Request, fn reviewFunc) {
+	orgID := chi.URLParam(r, "org_id")
+	correctionID := chi.URLParam(r, "correction_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(correctionID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "correction not found")
+		return
+	}
+
+	// The review note is optional, so an empty body is allowed.
+	var req domain.CorrectionReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	correction, err := fn(r.Context(), userID, orgID, correctionID, req.Note)
+	if err != nil {
+		switch err.Error() {
+		case "unauthorized":
+			response.WriteError(w, http.StatusForbidden, err.Error())
+		case "correction not found", "attendance not found":
+			response.WriteError(w, http.StatusNotFound, err.Error())
+		case "correction already reviewed":
+			response.WriteError(w, http.StatusConflict, err.Error())
+		case "check-in and check-out times are required for a missed shift",
+			"attendance times cannot be in the future",
+			"check-out time must be after check-in time":
+			response.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, correction)
+}
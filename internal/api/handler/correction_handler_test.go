@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+)
+
+// MockCorrectionRepository is a mock implementation of port.CorrectionRepository
+type MockCorrectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCorrectionRepository) CreateCorrection(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	args := m.Called(ctx, correction)
+	return args.Error(0)
+}
+
+func (m *MockCorrectionRepository) GetCorrectionByID(ctx context.Context, id string) (*domain.AttendanceCorrection, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttendanceCorrection), args.Error(1)
+}
+
+func (m *MockCorrectionRepository) ListCorrections(ctx context.Context, filter domain.CorrectionFilter) ([]*domain.AttendanceCorrection, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AttendanceCorrection), args.Error(1)
+}
+
+func (m *MockCorrectionRepository) ReviewCorrection(ctx context.Context, correction *domain.AttendanceCorrection) (bool, error) {
+	args := m.Called(ctx, correction)
+	return args.Bool(0), args.Error(1)
+}
+
+const (
+	testAttendanceID = "123e4567-e89b-12d3-a456-426614174010"
+	testCorrectionID = "123e4567-e89b-12d3-a456-426614174011"
+)
+
+func TestSubmitCorrection(t *testing.T) {
+	checkIn := time.Now().Add(-9 * time.Hour).Truncate(time.Second)
+	checkOut := checkIn.Add(8 * time.Hour)
+
+	tests := []struct {
+		name           string
+		input          map[string]interface{}
+		mockSetup      func(*MockCorrectionRepository, *MockAttendanceRepository)
+		expectedStatus int
+	}{
+		{
+			name:  "Success - Missing Check-out",
+			input: map[string]interface{}{"check_out_time": checkOut, "reason": "Forgot to check out"},
+			mockSetup: func(m *MockCorrectionRepository, a *MockAttendanceRepository) {
+				a.On("GetAttendanceByID", mock.Anything, testAttendanceID).Return(&domain.Attendance{ID: testAttendanceID, UserID: "user-123", OrgID: "org-1", CheckInTime: checkIn, Status: "PRESENT"}, nil)
+				m.On("CreateCorrection", mock.Anything, mock.MatchedBy(func(c *domain.AttendanceCorrection) bool {
+					return c.Status == domain.CorrectionStatusPending && c.OrgID == "org-1" && c.OriginalCheckInTime.Equal(checkIn) && c.RequestedCheckOutTime.Equal(checkOut)
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:  "Another User's Attendance",
+			input: map[string]interface{}{"check_out_time": checkOut, "reason": "Forgot to check out"},
+			mockSetup: func(m *MockCorrectionRepository, a *MockAttendanceRepository) {
+				a.On("GetAttendanceByID", mock.Anything, testAttendanceID).Return(&domain.Attendance{ID: testAttendanceID, UserID: "user-456", CheckInTime: checkIn}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:  "Missed Shift Needs Both Times",
+			input: map[string]interface{}{"check_in_time": checkIn, "reason": "Was on site"},
+			mockSetup: func(m *MockCorrectionRepository, a *MockAttendanceRepository) {
+				a.On("GetAttendanceByID", mock.Anything, testAttendanceID).Return(&domain.Attendance{ID: testAttendanceID, UserID: "user-123", CheckInTime: checkIn, Status: "ABSENT"}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Already Pending",
+			input: map[string]interface{}{"check_out_time": checkOut, "reason": "Forgot to check out"},
+			mockSetup: func(m *MockCorrectionRepository, a *MockAttendanceRepository) {
+				a.On("GetAttendanceByID", mock.Anything, testAttendanceID).Return(&domain.Attendance{ID: testAttendanceID, UserID: "user-123", CheckInTime: checkIn, Status: "PRESENT"}, nil)
+				m.On("CreateCorrection", mock.Anything, mock.Anything).Return(&domain.DuplicateError{Field: "pending correction"})
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Missing Reason",
+			input:          map[string]interface{}{"check_out_time": checkOut},
+			mockSetup:      func(m *MockCorrectionRepository, a *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCorrectionRepository)
+			mockAttRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo, mockAttRepo)
+
+			svc := service.NewCorrectionService(mockRepo, mockAttRepo, new(MockOrgRepository), new(MockTransactionManager))
+			handler := NewCorrectionHandler(svc)
+
+			r := chi.NewRouter()
+			r.Post("/attendance/{attendance_id}/corrections", handler.SubmitCorrection)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/attendance/"+testAttendanceID+"/corrections", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+			mockAttRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReviewCorrection(t *testing.T) {
+	scheduledStart := time.Now().Add(-24 * time.Hour).Truncate(time.Minute)
+	scheduledEnd := scheduledStart.Add(8 * time.Hour)
+	requestedIn := scheduledStart.Add(20 * time.Minute)
+	requestedOut := scheduledEnd.Add(30 * time.Minute)
+
+	pending := func() *domain.AttendanceCorrection {
+		return &domain.AttendanceCorrection{
+			ID:                    testCorrectionID,
+			AttendanceID:          testAttendanceID,
+			UserID:                "user-456",
+			OrgID:                 "org-1",
+			RequestedCheckInTime:  &requestedIn,
+			RequestedCheckOutTime: &requestedOut,
+			Status:                domain.CorrectionStatusPending,
+		}
+	}
+	absence := func() *domain.Attendance {
+		return &domain.Attendance{
+			ID:             testAttendanceID,
+			UserID:         "user-456",
+			OrgID:          "org-1",
+			CheckInTime:    scheduledStart,
+			Status:         domain.AttendanceStatusAbsent,
+			ScheduledStart: &scheduledStart,
+			ScheduledEnd:   &scheduledEnd,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		action         string
+		role           string
+		mockSetup      func(*MockCorrectionRepository, *MockAttendanceRepository)
+		expectedStatus int
+		expectedState  string
+	}{
+		{
+			name:   "Approve Missed Shift As Late",
+			action: "approve",
+			role:   "MANAGER",
+			mockSetup: func(m *MockCorrectionRepository, a *MockAttendanceRepository) {
+				m.On("GetCorrectionByID", mock.Anything, testCorrectionID).Return(pending(), nil)
+				a.On("GetAttendanceByID", mock.Anything, testAttendanceID).Return(absence(), nil)
+				m.On("ReviewCorrection", mock.Anything, mock.MatchedBy(func(c *domain.AttendanceCorrection) bool {
+					return c.Status == domain.CorrectionStatusApproved && c.OriginalStatus == domain.AttendanceStatusAbsent && c.OriginalCheckInTime.Equal(scheduledStart)
+				})).Return(true, nil)
+				a.On("GetMemberGroup", mock.Anything, "org-1", "user-456", mock.Anything).Return(&domain.Group{ID: "group-1"}, &domain.Schedule{Shift: &domain.Shift{AllowedLateMinutes: 10}}, nil)
+				a.On("UpdateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+					return att.CheckInTime.Equal(requestedIn) && att.CheckOutTime.Equal(requestedOut) &&
+						att.Status == domain.AttendanceStatusLate && att.LateMinutes == 20 && att.OvertimeMinutes == 30
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedState:  domain.CorrectionStatusApproved,
+		},
+		{
+			name:   "Reject",
+			action: "reject",
+			role:   "OWNER",
+			mockSetup: func(m *MockCorrectionRepository, a *MockAttendanceRepository) {
+				m.On("GetCorrectionByID", mock.Anything, testCorrectionID).Return(pending(), nil)
+				m.On("ReviewCorrection", mock.Anything, mock.MatchedBy(func(c *domain.AttendanceCorrection) bool {
+					return c.Status == domain.CorrectionStatusRejected && c.ReviewNote == "No record of this shift"
+				})).Return(true, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedState:  domain.CorrectionStatusRejected,
+		},
+		{
+			name:           "Forbidden - Employee",
+			action:         "approve",
+			role:           "EMPLOYEE",
+			mockSetup:      func(m *MockCorrectionRepository, a *MockAttendanceRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Already Reviewed",
+			action: "approve",
+			role:   "MANAGER",
+			mockSetup: func(m *MockCorrectionRepository, a *MockAttendanceRepository) {
+				c := pending()
+				c.Status = domain.CorrectionStatusRejected
+				m.On("GetCorrectionByID", mock.Anything, testCorrectionID).Return(c, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "Other Organization",
+			action: "reject",
+			role:   "MANAGER",
+			mockSetup: func(m *MockCorrectionRepository, a *MockAttendanceRepository) {
+				c := pending()
+				c.OrgID = "org-2"
+				m.On("GetCorrectionByID", mock.Anything, testCorrectionID).Return(c, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCorrectionRepository)
+			mockAttRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo, mockAttRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			mockOrgRepo.On("GetMember", mock.Anything, "org-1", "user-123").Return(&domain.OrganizationMember{Role: tt.role}, nil)
+
+			svc := service.NewCorrectionService(mockRepo, mockAttRepo, mockOrgRepo, new(MockTransactionManager))
+			handler := NewCorrectionHandler(svc)
+
+			r := chi.NewRouter()
+			r.Post("/organizations/{org_id}/corrections/{correction_id}/approve", handler.ApproveCorrection)
+			r.Post("/organizations/{org_id}/corrections/{correction_id}/reject", handler.RejectCorrection)
+
+			body, _ := json.Marshal(domain.CorrectionReviewRequest{Note: "No record of this shift"})
+			req, _ := http.NewRequest("POST", "/organizations/org-1/corrections/"+testCorrectionID+"/"+tt.action, bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedState != "" {
+				var correction domain.AttendanceCorrection
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &correction))
+				assert.Equal(t, tt.expectedState, correction.Status)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAttRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+	"github.com/syst3mctl/check-in-api/internal/pkg/response"
+	"github.com/syst3mctl/check-in-api/internal/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type KioskHandler struct {
+	svc *service.KioskService
+}
+
+func NewKioskHandler(svc *service.KioskService) *KioskHandler {
+	return &KioskHandler{svc: svc}
+}
+
+// RegisterKiosk godoc
+// @Summary Register a kiosk
+// @Description Register a shared device that checks members in by PIN or badge (Owner/Manager only). The device token is returned only once.
+// @Tags Kiosk
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param request body domain.Kiosk true "Kiosk Request"
+// @Success 201 {object} domain.KioskRegistration
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/kiosks [post]
+func (h *KioskHandler) RegisterKiosk(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+	var req domain.Kiosk
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	registration, err := h.svc.RegisterKiosk(r.Context(), userID, orgID, &req)
+	if err != nil {
+		if err.Error() == "unauthorized" {
+			response.WriteError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, registration)
+}
+
+// ListKiosks godoc
+// @Summary List kiosks
+// @Description List the organization's kiosks, including revoked ones (Owner/Manager only)
+// @Tags Kiosk
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Success 200 {array} domain.Kiosk
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/kiosks [get]
+func (h *KioskHandler) ListKiosks(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+
+	kiosks, err := h.svc.ListKiosks(r.Context(), userID, orgID)
+	if err != nil {
+		if err.Error() == "unauthorized" {
+			response.WriteError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, kiosks)
+}
+
+// RevokeKiosk godoc
+// @Summary Revoke a kiosk
+// @Description Stop the kiosk's device token from working (Owner/Manager only)
+// @Tags Kiosk
+// @Security BearerAuth
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param kiosk_id path string true "Kiosk ID"
+// @Success 204
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "kiosk not found"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/kiosks/{kiosk_id} [delete]
+func (h *KioskHandler) RevokeKiosk(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	kioskID := chi.URLParam(r, "kiosk_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(kioskID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "kiosk not found")
+		return
+	}
+
+	if err := h.svc.RevokeKiosk(r.Context(), userID, orgID, kioskID); err != nil {
+		switch err.Error() {
+		case "unauthorized":
+			response.WriteError(w, http.StatusForbidden, err.Error())
+		case "kiosk not found":
+			response.WriteError(w, http.StatusNotFound, err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetMemberCredentials godoc
+// @Summary Set a member's kiosk PIN and badge
+// @Description Replace the PIN and badge number a member uses at the organization's kiosks; an empty value removes it (Owner/Manager only). PINs and badges are unique within the organization.
+// @Tags Kiosk
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Param request body domain.KioskCredentialsRequest true "Kiosk Credentials Request"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "member not found"
+// @Failure 409 {object} domain.ErrorResponse "pin or badge id already exists"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/members/{user_id}/kiosk-credentials [put]
+func (h *KioskHandler) SetMemberCredentials(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	memberID := chi.URLParam(r, "user_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(memberID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "member not found")
+		return
+	}
+	var req domain.KioskCredentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	if err := h.svc.SetMemberCredentials(r.Context(), userID, orgID, memberID, &req); err != nil {
+		var dupErr *domain.DuplicateError
+		if errors.As(err, &dupErr) {
+			response.WriteError(w, http.StatusConflict, dupErr.Error())
+			return
+		}
+		switch err.Error() {
+		case "unauthorized":
+			response.WriteError(w, http.StatusForbidden, err.Error())
+		case "member not found":
+			response.WriteError(w, http.StatusNotFound, err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckIn godoc
+// @Summary Kiosk check-in
+// @Description Check in the member identified by PIN or badge at the kiosk. The kiosk stands in for the member's location.
+// @Tags Kiosk
+// @Security KioskAuth
+// @Accept json
+// @Produce json
+// @Param request body domain.KioskPunchRequest true "PIN or badge"
+// @Success 200 {object} domain.Attendance
+// @Failure 400 {object} domain.ErrorResponse "invalid request body, validation errors or already checked in"
+// @Failure 401 {object} domain.ErrorResponse "invalid kiosk token, or unknown pin or badge"
+// @Failure 409 {object} domain.ErrorResponse "a concurrent check-in opened a session first"
+// @Failure 429 {object} domain.ErrorResponse "too many failed attempts"
+// @Router /kiosk/check-in [post]
+func (h *KioskHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	kiosk := r.Context().Value("kiosk").(*domain.Kiosk)
+	var req domain.KioskPunchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	clientIP, _ := r.Context().Value("client_ip").(string)
+	result, err := h.svc.CheckIn(r.Context(), kiosk, &req, clientIP)
+	if err != nil {
+		var dupErr *domain.DuplicateError
+		if errors.As(err, &dupErr) {
+			response.WriteError(w, http.StatusConflict, "already checked in")
+			return
+		}
+		writeKioskPunchError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, result)
+}
+
+// CheckOut godoc
+// @Summary Kiosk check-out
+// @Description Check out the member identified by PIN or badge from their open session in the kiosk's organization.
+// @Tags Kiosk
+// @Security KioskAuth
+// @Accept json
+// @Produce json
+// @Param request body domain.KioskPunchRequest true "PIN or badge"
+// @Success 200 {object} domain.Attendance
+// @Failure 400 {object} domain.ErrorResponse "invalid request body, validation errors or not checked in"
+// @Failure 401 {object} domain.ErrorResponse "invalid kiosk token, or unknown pin or badge"
+// @Failure 429 {object} domain.ErrorResponse "too many failed attempts"
+// @Router /kiosk/check-out [post]
+func (h *KioskHandler) CheckOut(w http.ResponseWriter, r *http.Request) {
+	kiosk := r.Context().Value("kiosk").(*domain.Kiosk)
+	var req domain.KioskPunchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	result, err := h.svc.CheckOut(r.Context(), kiosk, &req)
+	if err != nil {
+		writeKioskPunchError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, result)
+}
+
+func writeKioskPunchError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "unknown pin or badge":
+		response.WriteError(w, http.StatusUnauthorized, err.Error())
+	case "too many failed attempts, try again later":
+		response.WriteError(w, http.StatusTooManyRequests, err.Error())
+	default:
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,450 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+)
+
+// MockKioskRepository is a mock implementation of port.KioskRepository
+type MockKioskRepository struct {
+	mock.Mock
+}
+
+func (m *MockKioskRepository) CreateKiosk(ctx context.Context, kiosk *domain.Kiosk) error {
+	args := m.Called(ctx, kiosk)
+	return args.Error(0)
+}
+
+func (m *MockKioskRepository) GetKioskByID(ctx context.Context, id string) (*domain.Kiosk, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Kiosk), args.Error(1)
+}
+
+func (m *MockKioskRepository) GetKioskByTokenHash(ctx context.Context, tokenHash string) (*domain.Kiosk, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Kiosk), args.Error(1)
+}
+
+func (m *MockKioskRepository) ListKiosks(ctx context.Context, orgID string) ([]*domain.Kiosk, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Kiosk), args.Error(1)
+}
+
+func (m *MockKioskRepository) RevokeKiosk(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockKioskRepository) RecordFailedAttempt(ctx context.Context, id, source string, at, since time.Time) (int, error) {
+	args := m.Called(ctx, id, source, at, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockKioskRepository) LockKiosk(ctx context.Context, id string, lockedUntil time.Time) error {
+	args := m.Called(ctx, id, lockedUntil)
+	return args.Error(0)
+}
+
+func (m *MockKioskRepository) SetMemberCredentials(ctx context.Context, orgID, userID string, pinHash, badgeID *string) (bool, error) {
+	args := m.Called(ctx, orgID, userID, pinHash, badgeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockKioskRepository) FindMemberByPIN(ctx context.Context, orgID, pinHash string) (string, error) {
+	args := m.Called(ctx, orgID, pinHash)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockKioskRepository) FindMemberByBadge(ctx context.Context, orgID, badgeID string) (string, error) {
+	args := m.Called(ctx, orgID, badgeID)
+	return args.String(0), args.Error(1)
+}
+
+func TestRegisterKiosk(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		input          map[string]interface{}
+		expectCreate   bool
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			role:           "OWNER",
+			input:          map[string]interface{}{"name": "Entrance tablet"},
+			expectCreate:   true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Missing Name",
+			role:           "OWNER",
+			input:          map[string]interface{}{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Forbidden - Employee",
+			role:           "EMPLOYEE",
+			input:          map[string]interface{}{"name": "Entrance tablet"},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockKioskRepository)
+			var created *domain.Kiosk
+			if tt.expectCreate {
+				mockRepo.On("CreateKiosk", mock.Anything, mock.AnythingOfType("*domain.Kiosk")).Return(nil).Run(func(args mock.Arguments) {
+					created = args.Get(1).(*domain.Kiosk)
+				})
+			}
+			mockOrgRepo := new(MockOrgRepository)
+			mockOrgRepo.On("GetMember", mock.Anything, "org-1", "user-123").Return(&domain.OrganizationMember{Role: tt.role}, nil)
+
+			attSvc := service.NewAttendanceService(new(MockAttendanceRepository), mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewKioskHandler(service.NewKioskService(mockRepo, attSvc, "pin-secret", 5, 15*time.Minute, 5*time.Minute))
+
+			r := chi.NewRouter()
+			r.Post("/organizations/{org_id}/kiosks", handler.RegisterKiosk)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/organizations/org-1/kiosks", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if rr.Code == http.StatusCreated {
+				var resp domain.KioskRegistration
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.NotEmpty(t, resp.Token)
+				// Only the hash of the device token is stored
+				sum := sha256.Sum256([]byte(resp.Token))
+				assert.Equal(t, hex.EncodeToString(sum[:]), created.TokenHash)
+				assert.Equal(t, "org-1", created.OrgID)
+				assert.NotContains(t, rr.Body.String(), created.TokenHash)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetMemberCredentials(t *testing.T) {
+	memberID := "123e4567-e89b-12d3-a456-426614174001"
+
+	tests := []struct {
+		name           string
+		role           string
+		input          map[string]interface{}
+		mockSetup      func(*MockKioskRepository)
+		expectedStatus int
+	}{
+		{
+			name:  "Success",
+			role:  "MANAGER",
+			input: map[string]interface{}{"pin": "4821", "badge_id": "B-100"},
+			mockSetup: func(m *MockKioskRepository) {
+				m.On("SetMemberCredentials", mock.Anything, "org-1", memberID, mock.MatchedBy(func(h *string) bool {
+					// The PIN itself is never stored
+					return h != nil && len(*h) == 64 && *h != "4821"
+				}), mock.MatchedBy(func(b *string) bool { return b != nil && *b == "B-100" })).Return(true, nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:  "Clear Credentials",
+			role:  "OWNER",
+			input: map[string]interface{}{},
+			mockSetup: func(m *MockKioskRepository) {
+				m.On("SetMemberCredentials", mock.Anything, "org-1", memberID, (*string)(nil), (*string)(nil)).Return(true, nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:  "PIN Taken",
+			role:  "OWNER",
+			input: map[string]interface{}{"pin": "4821"},
+			mockSetup: func(m *MockKioskRepository) {
+				m.On("SetMemberCredentials", mock.Anything, "org-1", memberID, mock.Anything, (*string)(nil)).Return(false, &domain.DuplicateError{Field: "pin"})
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:  "Not A Member",
+			role:  "OWNER",
+			input: map[string]interface{}{"badge_id": "B-100"},
+			mockSetup: func(m *MockKioskRepository) {
+				m.On("SetMemberCredentials", mock.Anything, "org-1", memberID, (*string)(nil), mock.Anything).Return(false, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "PIN Not Numeric",
+			role:           "OWNER",
+			input:          map[string]interface{}{"pin": "48a1"},
+			mockSetup:      func(m *MockKioskRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "PIN Too Short",
+			role:           "OWNER",
+			input:          map[string]interface{}{"pin": "482"},
+			mockSetup:      func(m *MockKioskRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Forbidden - Employee",
+			role:           "EMPLOYEE",
+			input:          map[string]interface{}{"pin": "4821"},
+			mockSetup:      func(m *MockKioskRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockKioskRepository)
+			tt.mockSetup(mockRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			mockOrgRepo.On("GetMember", mock.Anything, "org-1", "user-123").Return(&domain.OrganizationMember{Role: tt.role}, nil)
+
+			attSvc := service.NewAttendanceService(new(MockAttendanceRepository), mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewKioskHandler(service.NewKioskService(mockRepo, attSvc, "pin-secret", 5, 15*time.Minute, 5*time.Minute))
+
+			r := chi.NewRouter()
+			r.Put("/organizations/{org_id}/members/{user_id}/kiosk-credentials", handler.SetMemberCredentials)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("PUT", "/organizations/org-1/members/"+memberID+"/kiosk-credentials", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestKioskCheckIn(t *testing.T) {
+	orgID := "123e4567-e89b-12d3-a456-426614174000"
+	memberID := "user-456"
+	lockedUntil := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name           string
+		kiosk          domain.Kiosk
+		input          map[string]interface{}
+		mockSetup      func(*MockKioskRepository, *MockAttendanceRepository)
+		expectedStatus int
+	}{
+		{
+			name:  "Success - PIN",
+			kiosk: domain.Kiosk{ID: "kiosk-1", OrgID: orgID},
+			input: map[string]interface{}{"pin": "4821"},
+			mockSetup: func(k *MockKioskRepository, a *MockAttendanceRepository) {
+				k.On("FindMemberByPIN", mock.Anything, orgID, mock.AnythingOfType("string")).Return(memberID, nil)
+				a.On("ListOpenAttendance", mock.Anything, memberID, orgID).Return(nil, nil)
+				a.On("GetMemberGroup", mock.Anything, orgID, memberID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				a.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+					return att.UserID == memberID && att.ProofType == domain.ProofTypeKiosk && *att.KioskID == "kiosk-1"
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Success - Badge",
+			kiosk: domain.Kiosk{ID: "kiosk-1", OrgID: orgID},
+			input: map[string]interface{}{"badge_id": "B-100"},
+			mockSetup: func(k *MockKioskRepository, a *MockAttendanceRepository) {
+				k.On("FindMemberByBadge", mock.Anything, orgID, "B-100").Return(memberID, nil)
+				a.On("ListOpenAttendance", mock.Anything, memberID, orgID).Return(nil, nil)
+				a.On("GetMemberGroup", mock.Anything, orgID, memberID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				a.On("CreateAttendance", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Unknown PIN",
+			kiosk: domain.Kiosk{ID: "kiosk-1", OrgID: orgID},
+			input: map[string]interface{}{"pin": "0000"},
+			mockSetup: func(k *MockKioskRepository, a *MockAttendanceRepository) {
+				k.On("FindMemberByPIN", mock.Anything, orgID, mock.AnythingOfType("string")).Return("", nil)
+				k.On("RecordFailedAttempt", mock.Anything, "kiosk-1", "PIN", mock.AnythingOfType("time.Time"), mock.MatchedBy(func(since time.Time) bool {
+					return time.Since(since) > 14*time.Minute
+				})).Return(1, nil)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "Unknown Badge Locks Kiosk",
+			kiosk: domain.Kiosk{ID: "kiosk-1", OrgID: orgID},
+			input: map[string]interface{}{"badge_id": "B-999"},
+			mockSetup: func(k *MockKioskRepository, a *MockAttendanceRepository) {
+				k.On("FindMemberByBadge", mock.Anything, orgID, "B-999").Return("", nil)
+				k.On("RecordFailedAttempt", mock.Anything, "kiosk-1", "BADGE", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(5, nil)
+				k.On("LockKiosk", mock.Anything, "kiosk-1", mock.MatchedBy(func(until time.Time) bool {
+					return time.Until(until) > 4*time.Minute
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Locked Out",
+			kiosk:          domain.Kiosk{ID: "kiosk-1", OrgID: orgID, LockedUntil: &lockedUntil},
+			input:          map[string]interface{}{"pin": "4821"},
+			mockSetup:      func(k *MockKioskRepository, a *MockAttendanceRepository) {},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:  "Already Checked In",
+			kiosk: domain.Kiosk{ID: "kiosk-1", OrgID: orgID},
+			input: map[string]interface{}{"pin": "4821"},
+			mockSetup: func(k *MockKioskRepository, a *MockAttendanceRepository) {
+				k.On("FindMemberByPIN", mock.Anything, orgID, mock.AnythingOfType("string")).Return(memberID, nil)
+				a.On("ListOpenAttendance", mock.Anything, memberID, orgID).Return([]*domain.Attendance{{ID: "att-1"}}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Neither PIN Nor Badge",
+			kiosk:          domain.Kiosk{ID: "kiosk-1", OrgID: orgID},
+			input:          map[string]interface{}{"note": "hello"},
+			mockSetup:      func(k *MockKioskRepository, a *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockKioskRepository)
+			mockAttRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo, mockAttRepo)
+			// Kiosk check-ins skip the organization geofence, so the organization is never loaded
+			mockOrgRepo := new(MockOrgRepository)
+
+			attSvc := service.NewAttendanceService(mockAttRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewKioskHandler(service.NewKioskService(mockRepo, attSvc, "pin-secret", 5, 15*time.Minute, 5*time.Minute))
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/kiosk/check-in", bytes.NewBuffer(body))
+			kiosk := tt.kiosk
+			ctx := context.WithValue(req.Context(), "kiosk", &kiosk)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler.CheckIn(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+			mockAttRepo.AssertExpectations(t)
+			mockOrgRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestKioskLockoutSurvivesSuccess checks that a member entering their own PIN
+// between guesses does not reset the kiosk's failure count.
+func TestKioskLockoutSurvivesSuccess(t *testing.T) {
+	orgID := "123e4567-e89b-12d3-a456-426614174000"
+	memberID := "user-456"
+	kiosk := &domain.Kiosk{ID: "kiosk-1", OrgID: orgID}
+
+	mockRepo := new(MockKioskRepository)
+	mockRepo.On("FindMemberByPIN", mock.Anything, orgID, mock.AnythingOfType("string")).Return("", nil).Once()
+	mockRepo.On("FindMemberByPIN", mock.Anything, orgID, mock.AnythingOfType("string")).Return(memberID, nil).Once()
+	mockRepo.On("FindMemberByPIN", mock.Anything, orgID, mock.AnythingOfType("string")).Return("", nil).Once()
+	mockRepo.On("RecordFailedAttempt", mock.Anything, "kiosk-1", "PIN", mock.Anything, mock.Anything).Return(1, nil).Once()
+	mockRepo.On("RecordFailedAttempt", mock.Anything, "kiosk-1", "PIN", mock.Anything, mock.Anything).Return(2, nil).Once()
+	mockRepo.On("LockKiosk", mock.Anything, "kiosk-1", mock.AnythingOfType("time.Time")).Run(func(args mock.Arguments) {
+		until := args.Get(2).(time.Time)
+		kiosk.LockedUntil = &until
+	}).Return(nil).Once()
+	mockAttRepo := new(MockAttendanceRepository)
+	mockAttRepo.On("ListOpenAttendance", mock.Anything, memberID, orgID).Return(nil, nil)
+	mockAttRepo.On("GetMemberGroup", mock.Anything, orgID, memberID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+	mockAttRepo.On("CreateAttendance", mock.Anything, mock.Anything).Return(nil)
+
+	attSvc := service.NewAttendanceService(mockAttRepo, new(MockOrgRepository), new(MockTransactionManager), service.RiskPolicy{})
+	handler := NewKioskHandler(service.NewKioskService(mockRepo, attSvc, "pin-secret", 2, 15*time.Minute, 5*time.Minute))
+
+	for _, step := range []struct {
+		pin            string
+		expectedStatus int
+	}{
+		{"0000", http.StatusUnauthorized},
+		{"4821", http.StatusOK},
+		{"1111", http.StatusUnauthorized}, // Second failure in the window locks the kiosk
+		{"2222", http.StatusTooManyRequests},
+	} {
+		body, _ := json.Marshal(map[string]interface{}{"pin": step.pin})
+		req, _ := http.NewRequest("POST", "/kiosk/check-in", bytes.NewBuffer(body))
+		ctx := context.WithValue(req.Context(), "kiosk", kiosk)
+		req = req.WithContext(ctx)
+
+		rr := httptest.NewRecorder()
+		handler.CheckIn(rr, req)
+
+		assert.Equal(t, step.expectedStatus, rr.Code, "pin %s", step.pin)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestKioskCheckOut(t *testing.T) {
+	orgID := "123e4567-e89b-12d3-a456-426614174000"
+	memberID := "user-456"
+	kiosk := &domain.Kiosk{ID: "kiosk-1", OrgID: orgID}
+
+	mockRepo := new(MockKioskRepository)
+	mockRepo.On("FindMemberByBadge", mock.Anything, orgID, "B-100").Return(memberID, nil)
+	mockAttRepo := new(MockAttendanceRepository)
+	mockAttRepo.On("ListOpenAttendance", mock.Anything, memberID, orgID).Return([]*domain.Attendance{{ID: "att-1", UserID: memberID, OrgID: orgID, CheckInTime: time.Now().Add(-8 * time.Hour)}}, nil)
+	mockAttRepo.On("EndBreak", mock.Anything, "att-1", mock.AnythingOfType("time.Time")).Return(nil, nil)
+	mockAttRepo.On("UpdateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+		return att.CheckOutTime != nil && att.CheckOutKioskID != nil && *att.CheckOutKioskID == "kiosk-1"
+	})).Return(nil)
+	// No location is sent, and the organization geofence is not consulted
+	mockOrgRepo := new(MockOrgRepository)
+
+	attSvc := service.NewAttendanceService(mockAttRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+	handler := NewKioskHandler(service.NewKioskService(mockRepo, attSvc, "pin-secret", 5, 15*time.Minute, 5*time.Minute))
+
+	body, _ := json.Marshal(map[string]interface{}{"badge_id": "B-100"})
+	req, _ := http.NewRequest("POST", "/kiosk/check-out", bytes.NewBuffer(body))
+	ctx := context.WithValue(req.Context(), "kiosk", kiosk)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+	handler.CheckOut(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockRepo.AssertExpectations(t)
+	mockAttRepo.AssertExpectations(t)
+	mockOrgRepo.AssertExpectations(t)
+}
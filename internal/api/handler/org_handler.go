@@ -199,7 +199,7 @@ func (h *OrgHandler) InviteEmployee(w http.ResponseWriter, r *http.Request) {
 
 // CreateShift godoc
 // @Summary Create a shift
-// @Description Create a new shift for the organization
+// @Description Create a new shift for the organization. A split shift, such as 07:00-11:00 and 16:00-20:00, lists its segments in place of start_time and end_time; lateness and early leave are evaluated per segment.
 // @Tags Organization
 // @Security BearerAuth
 // @Accept json
@@ -208,6 +208,7 @@ func (h *OrgHandler) InviteEmployee(w http.ResponseWriter, r *http.Request) {
 // @Param request body domain.Shift true "Shift Request"
 // @Success 201 {object} domain.Shift
 // @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 404 {object} domain.ErrorResponse "site not found"
 // @Failure 500 {object} domain.ErrorResponse "internal server error"
 // @Router /organizations/{org_id}/shifts [post]
 func (h *OrgHandler) CreateShift(w http.ResponseWriter, r *http.Request) {
@@ -226,6 +227,14 @@ func (h *OrgHandler) CreateShift(w http.ResponseWriter, r *http.Request) {
 
 	shift, err := h.svc.CreateShift(r.Context(), &req)
 	if err != nil {
+		switch err.Error() {
+		case "site not found":
+			response.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		case "shift segments must span less than a day":
+			response.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -235,7 +244,7 @@ func (h *OrgHandler) CreateShift(w http.ResponseWriter, r *http.Request) {
 
 // CreateGroup godoc
 // @Summary Create a group
-// @Description Create a new group/team for the organization
+// @Description Create a new group/team for the organization, working to a shift or a rotation
 // @Tags Organization
 // @Security BearerAuth
 // @Accept json
@@ -244,6 +253,7 @@ func (h *OrgHandler) CreateShift(w http.ResponseWriter, r *http.Request) {
 // @Param request body domain.Group true "Group Request"
 // @Success 201 {object} domain.Group
 // @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 404 {object} domain.ErrorResponse "rotation or site not found"
 // @Failure 500 {object} domain.ErrorResponse "internal server error"
 // @Router /organizations/{org_id}/groups [post]
 func (h *OrgHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
@@ -262,6 +272,14 @@ func (h *OrgHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
 
 	group, err := h.svc.CreateGroup(r.Context(), &req)
 	if err != nil {
+		switch err.Error() {
+		case "site not found", "rotation not found":
+			response.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		case "only one of shift_id and rotation_id can be set":
+			response.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		response.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -406,3 +424,456 @@ func (h *OrgHandler) RemoveEmployee(w http.ResponseWriter, r *http.Request) {
 
 	response.WriteJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
+
+// CreateSite godoc
+// @Summary Create a site
+// @Description Add a work site, such as a branch, to the organization (Owner/Manager only). Site names are unique within the organization.
+// @Tags Site
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param request body domain.Site true "Site Request"
+// @Success 201 {object} domain.Site
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 409 {object} domain.ErrorResponse "a site with this name already exists"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/sites [post]
+func (h *OrgHandler) CreateSite(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+	var req domain.Site
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	site, err := h.svc.CreateSite(r.Context(), userID, orgID, &req)
+	if err != nil {
+		writeSiteError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, site)
+}
+
+// ListSites godoc
+// @Summary List sites
+// @Description List the organization's work sites by name
+// @Tags Site
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Success 200 {array} domain.Site
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/sites [get]
+func (h *OrgHandler) ListSites(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+
+	sites, err := h.svc.ListSites(r.Context(), userID, orgID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, sites)
+}
+
+// GetSite godoc
+// @Summary Get a site
+// @Description Get one of the organization's work sites
+// @Tags Site
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param site_id path string true "Site ID"
+// @Success 200 {object} domain.Site
+// @Failure 404 {object} domain.ErrorResponse "site not found"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/sites/{site_id} [get]
+func (h *OrgHandler) GetSite(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	siteID := chi.URLParam(r, "site_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(siteID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "site not found")
+		return
+	}
+
+	site, err := h.svc.GetSite(r.Context(), userID, orgID, siteID)
+	if err != nil {
+		writeSiteError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, site)
+}
+
+// UpdateSite godoc
+// @Summary Update a site
+// @Description Replace a work site's details (Owner/Manager only)
+// @Tags Site
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param site_id path string true "Site ID"
+// @Param request body domain.Site true "Site Request"
+// @Success 200 {object} domain.Site
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "site not found"
+// @Failure 409 {object} domain.ErrorResponse "a site with this name already exists"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/sites/{site_id} [put]
+func (h *OrgHandler) UpdateSite(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	siteID := chi.URLParam(r, "site_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(siteID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "site not found")
+		return
+	}
+	var req domain.Site
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.ID = siteID
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	site, err := h.svc.UpdateSite(r.Context(), userID, orgID, &req)
+	if err != nil {
+		writeSiteError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, site)
+}
+
+// DeleteSite godoc
+// @Summary Delete a site
+// @Description Delete a work site (Owner/Manager only). Groups, shifts, tasks and attendance at the site are left without one.
+// @Tags Site
+// @Security BearerAuth
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param site_id path string true "Site ID"
+// @Success 204
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "site not found"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/sites/{site_id} [delete]
+func (h *OrgHandler) DeleteSite(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	siteID := chi.URLParam(r, "site_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(siteID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "site not found")
+		return
+	}
+
+	if err := h.svc.DeleteSite(r.Context(), userID, orgID, siteID); err != nil {
+		writeSiteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeSiteError(w http.ResponseWriter, err error) {
+	var dupErr *domain.DuplicateError
+	switch {
+	case errors.As(err, &dupErr):
+		response.WriteError(w, http.StatusConflict, "a site with this name already exists")
+	case err.Error() == "unauthorized":
+		response.WriteError(w, http.StatusForbidden, err.Error())
+	case err.Error() == "site not found":
+		response.WriteError(w, http.StatusNotFound, err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// CreateScheduleAssignment godoc
+// @Summary Assign a member a schedule
+// @Description Put a member on a shift or a rotation from effective_from through effective_to, overriding their group's schedule on those dates (Owner/Manager only). An open-ended assignment in effect then is ended the day before; other overlaps are refused. Dates are in the shift's or rotation's timezone and cannot be in the past.
+// @Tags Schedule
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Param request body domain.ScheduleAssignment true "Schedule Assignment Request"
+// @Success 201 {object} domain.ScheduleAssignment
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "shift or rotation not found"
+// @Failure 409 {object} domain.ErrorResponse "schedule assignment overlaps another"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/members/{user_id}/schedule-assignments [post]
+func (h *OrgHandler) CreateScheduleAssignment(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	targetUserID := chi.URLParam(r, "user_id")
+	userID := r.Context().Value("user_id").(string)
+	var req domain.ScheduleAssignment
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	assignment, err := h.svc.CreateScheduleAssignment(r.Context(), userID, orgID, targetUserID, &req)
+	if err != nil {
+		writeScheduleAssignmentError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, assignment)
+}
+
+// ListScheduleAssignments godoc
+// @Summary List a member's schedule assignments
+// @Description List a member's schedule assignments by effective date, past ones included (Owner/Manager, or the member themself)
+// @Tags Schedule
+// @Security BearerAuth
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Success 200 {array} domain.ScheduleAssignment
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/members/{user_id}/schedule-assignments [get]
+func (h *OrgHandler) ListScheduleAssignments(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	targetUserID := chi.URLParam(r, "user_id")
+	userID := r.Context().Value("user_id").(string)
+
+	assignments, err := h.svc.ListScheduleAssignments(r.Context(), userID, orgID, targetUserID)
+	if err != nil {
+		writeScheduleAssignmentError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, assignments)
+}
+
+// EndScheduleAssignment godoc
+// @Summary End a schedule assignment
+// @Description Set the last day of a member's schedule assignment, after which they are back on their group's schedule (Owner/Manager only). It cannot end before today.
+// @Tags Schedule
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Param assignment_id path string true "Schedule Assignment ID"
+// @Param request body domain.EndScheduleAssignmentRequest true "End Request"
+// @Success 200 {object} domain.ScheduleAssignment
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "schedule assignment not found"
+// @Failure 409 {object} domain.ErrorResponse "schedule assignment overlaps another"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/members/{user_id}/schedule-assignments/{assignment_id}/end [post]
+func (h *OrgHandler) EndScheduleAssignment(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	targetUserID := chi.URLParam(r, "user_id")
+	assignmentID := chi.URLParam(r, "assignment_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(assignmentID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "schedule assignment not found")
+		return
+	}
+	var req domain.EndScheduleAssignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	assignment, err := h.svc.EndScheduleAssignment(r.Context(), userID, orgID, targetUserID, assignmentID, req.EffectiveTo)
+	if err != nil {
+		writeScheduleAssignmentError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, assignment)
+}
+
+// DeleteScheduleAssignment godoc
+// @Summary Delete a schedule assignment
+// @Description Delete a member's schedule assignment that has not yet taken effect (Owner/Manager only). Assignments already in effect are ended instead, keeping the history.
+// @Tags Schedule
+// @Security BearerAuth
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Param assignment_id path string true "Schedule Assignment ID"
+// @Success 204
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "schedule assignment not found"
+// @Failure 409 {object} domain.ErrorResponse "schedule assignment already in effect"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/members/{user_id}/schedule-assignments/{assignment_id} [delete]
+func (h *OrgHandler) DeleteScheduleAssignment(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	targetUserID := chi.URLParam(r, "user_id")
+	assignmentID := chi.URLParam(r, "assignment_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(assignmentID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "schedule assignment not found")
+		return
+	}
+
+	if err := h.svc.DeleteScheduleAssignment(r.Context(), userID, orgID, targetUserID, assignmentID); err != nil {
+		writeScheduleAssignmentError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeScheduleAssignmentError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "unauthorized":
+		response.WriteError(w, http.StatusForbidden, err.Error())
+	case "shift not found", "rotation not found", "schedule assignment not found":
+		response.WriteError(w, http.StatusNotFound, err.Error())
+	case "schedule assignment overlaps another", "schedule assignment already in effect":
+		response.WriteError(w, http.StatusConflict, err.Error())
+	case "exactly one of shift_id and rotation_id is required", "effective_from cannot be in the past", "effective_to cannot be before effective_from", "cannot end a schedule assignment before today":
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// CreateRotation godoc
+// @Summary Create a rotation
+// @Description Add a repeating cycle of shifts and days off, such as 4-on/4-off (Owner/Manager only). Each entry of days is a shift ID, or null for a day off; the first falls on anchor_date and the cycle repeats from there. The shifts must share a timezone, which the rotation takes. Rotation names are unique within the organization.
+// @Tags Schedule
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param request body domain.Rotation true "Rotation Request"
+// @Success 201 {object} domain.Rotation
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "shift not found"
+// @Failure 409 {object} domain.ErrorResponse "a rotation with this name already exists"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/rotations [post]
+func (h *OrgHandler) CreateRotation(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+	var req domain.Rotation
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	rotation, err := h.svc.CreateRotation(r.Context(), userID, orgID, &req)
+	if err != nil {
+		writeRotationError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, rotation)
+}
+
+// ListRotations godoc
+// @Summary List rotations
+// @Description List the organization's rotations by name
+// @Tags Schedule
+// @Security BearerAuth
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Success 200 {array} domain.Rotation
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/rotations [get]
+func (h *OrgHandler) ListRotations(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	userID := r.Context().Value("user_id").(string)
+
+	rotations, err := h.svc.ListRotations(r.Context(), userID, orgID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, rotations)
+}
+
+// GetRotation godoc
+// @Summary Get a rotation
+// @Description Get one of the organization's rotations
+// @Tags Schedule
+// @Security BearerAuth
+// @Produce json
+// @Param org_id path string true "Organization ID"
+// @Param rotation_id path string true "Rotation ID"
+// @Success 200 {object} domain.Rotation
+// @Failure 404 {object} domain.ErrorResponse "rotation not found"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/rotations/{rotation_id} [get]
+func (h *OrgHandler) GetRotation(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	rotationID := chi.URLParam(r, "rotation_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(rotationID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "rotation not found")
+		return
+	}
+
+	rotation, err := h.svc.GetRotation(r.Context(), userID, orgID, rotationID)
+	if err != nil {
+		writeRotationError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, rotation)
+}
+
+func writeRotationError(w http.ResponseWriter, err error) {
+	var dupErr *domain.DuplicateError
+	switch {
+	case errors.As(err, &dupErr):
+		response.WriteError(w, http.StatusConflict, "a rotation with this name already exists")
+	case err.Error() == "unauthorized":
+		response.WriteError(w, http.StatusForbidden, err.Error())
+	case err.Error() == "shift not found", err.Error() == "rotation not found":
+		response.WriteError(w, http.StatusNotFound, err.Error())
+	case err.Error() == "rotation shifts must share a timezone", err.Error() == "rotation needs at least one shift day":
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -98,14 +99,109 @@ func (m *MockOrgRepository) UpdateMemberGroup(ctx context.Context, orgID, userID
 	return args.Error(0)
 }
 
+func (m *MockOrgRepository) CreateSite(ctx context.Context, site *domain.Site) error {
+	args := m.Called(ctx, site)
+	return args.Error(0)
+}
+
+func (m *MockOrgRepository) GetSiteByID(ctx context.Context, id string) (*domain.Site, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Site), args.Error(1)
+}
+
+func (m *MockOrgRepository) ListSites(ctx context.Context, orgID string) ([]*domain.Site, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Site), args.Error(1)
+}
+
+func (m *MockOrgRepository) UpdateSite(ctx context.Context, site *domain.Site) (bool, error) {
+	args := m.Called(ctx, site)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrgRepository) DeleteSite(ctx context.Context, orgID, id string) (bool, error) {
+	args := m.Called(ctx, orgID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrgRepository) GetShiftByID(ctx context.Context, id string) (*domain.Shift, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Shift), args.Error(1)
+}
+
+func (m *MockOrgRepository) CreateScheduleAssignment(ctx context.Context, assignment *domain.ScheduleAssignment) error {
+	args := m.Called(ctx, assignment)
+	return args.Error(0)
+}
+
+func (m *MockOrgRepository) GetScheduleAssignmentByID(ctx context.Context, id string) (*domain.ScheduleAssignment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScheduleAssignment), args.Error(1)
+}
+
+func (m *MockOrgRepository) ListScheduleAssignments(ctx context.Context, orgID, userID string) ([]*domain.ScheduleAssignment, error) {
+	args := m.Called(ctx, orgID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ScheduleAssignment), args.Error(1)
+}
+
+func (m *MockOrgRepository) SetScheduleAssignmentEnd(ctx context.Context, id string, effectiveTo *string) error {
+	args := m.Called(ctx, id, effectiveTo)
+	return args.Error(0)
+}
+
+func (m *MockOrgRepository) DeleteScheduleAssignment(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOrgRepository) CreateRotation(ctx context.Context, rotation *domain.Rotation) error {
+	args := m.Called(ctx, rotation)
+	return args.Error(0)
+}
+
+func (m *MockOrgRepository) GetRotationByID(ctx context.Context, id string) (*domain.Rotation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Rotation), args.Error(1)
+}
+
+func (m *MockOrgRepository) ListRotations(ctx context.Context, orgID string) ([]*domain.Rotation, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Rotation), args.Error(1)
+}
+
 // MockTransactionManager is a mock implementation of port.TransactionManager
 type MockTransactionManager struct {
 	mock.Mock
 }
 
+// inTxKey marks the context passed to a MockTransactionManager transaction,
+// so tests can tell which writes a rollback would discard.
+type inTxKey struct{}
+
 func (m *MockTransactionManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
 	// Simply execute the function for testing purposes
-	return fn(ctx)
+	return fn(context.WithValue(ctx, inTxKey{}, true))
 }
 
 func TestCreateOrganization(t *testing.T) {
@@ -157,6 +253,19 @@ func TestCreateOrganization(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "Geofence Mode Without Radius",
+			input: domain.Organization{
+				Name:                "Test Org",
+				Email:               "test@example.com",
+				DefaultLocationLat:  10.0,
+				DefaultLocationLong: 20.0,
+				GeofenceMode:        domain.GeofenceModeReject,
+			},
+			mockSetup: func(m *MockOrgRepository) {
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -237,6 +346,7 @@ func TestUpdateOrganization(t *testing.T) {
 		orgID          string
 		userID         string
 		input          domain.Organization
+		rawBody        string // Sent in place of input when set
 		mockSetup      func(*MockOrgRepository)
 		expectedStatus int
 	}{
@@ -252,10 +362,58 @@ func TestUpdateOrganization(t *testing.T) {
 			},
 			mockSetup: func(m *MockOrgRepository) {
 				m.On("GetMember", mock.Anything, "org-123", "user-owner").Return(&domain.OrganizationMember{Role: "OWNER"}, nil)
-				m.On("UpdateOrganization", mock.Anything, mock.Anything).Return(nil)
+				m.On("GetOrganizationByID", mock.Anything, "org-123").Return(&domain.Organization{ID: "org-123", GeofenceMode: domain.GeofenceModeOff, NetworkMode: domain.GeofenceModeOff, LocationRule: domain.LocationRuleAll}, nil)
+				m.On("UpdateOrganization", mock.Anything, mock.MatchedBy(func(org *domain.Organization) bool {
+					return org.Name == "Updated Name" && org.GeofenceMode == domain.GeofenceModeOff
+				})).Return(nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:   "Partial Update Keeps Location Policy",
+			orgID:  "org-123",
+			userID: "user-owner",
+			input: domain.Organization{
+				Name:                "Updated Name",
+				Email:               "updated@example.com",
+				DefaultLocationLat:  10.0,
+				DefaultLocationLong: 20.0,
+				LocationRule:        domain.LocationRuleAny,
+			},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-owner").Return(&domain.OrganizationMember{Role: "OWNER"}, nil)
+				m.On("GetOrganizationByID", mock.Anything, "org-123").Return(&domain.Organization{
+					ID:                   "org-123",
+					GeofenceMode:         domain.GeofenceModeReject,
+					GeofenceRadiusMeters: 150,
+					NetworkMode:          domain.GeofenceModeWarn,
+					AllowedNetworks:      []string{"203.0.113.0/24"},
+					LocationRule:         domain.LocationRuleAll,
+				}, nil)
+				m.On("UpdateOrganization", mock.Anything, mock.MatchedBy(func(org *domain.Organization) bool {
+					return org.GeofenceMode == domain.GeofenceModeReject && org.GeofenceRadiusMeters == 150 &&
+						org.NetworkMode == domain.GeofenceModeWarn && len(org.AllowedNetworks) == 1 &&
+						org.LocationRule == domain.LocationRuleAny
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Emptied Networks While Enforced",
+			orgID:  "org-123",
+			userID: "user-owner",
+			// allowed_networks is omitted from a marshalled empty list
+			rawBody: `{"name": "Updated Name", "email": "updated@example.com", "default_location_lat": 10, "default_location_long": 20, "allowed_networks": []}`,
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-owner").Return(&domain.OrganizationMember{Role: "OWNER"}, nil)
+				m.On("GetOrganizationByID", mock.Anything, "org-123").Return(&domain.Organization{
+					ID:              "org-123",
+					NetworkMode:     domain.GeofenceModeReject,
+					AllowedNetworks: []string{"203.0.113.0/24"},
+				}, nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
 		{
 			name:   "Forbidden - Not Owner",
 			orgID:  "org-123",
@@ -285,6 +443,9 @@ func TestUpdateOrganization(t *testing.T) {
 			r.Put("/organizations/{org_id}", handler.UpdateOrganization)
 
 			body, _ := json.Marshal(tt.input)
+			if tt.rawBody != "" {
+				body = []byte(tt.rawBody)
+			}
 			req, _ := http.NewRequest("PUT", "/organizations/"+tt.orgID, bytes.NewBuffer(body))
 			ctx := context.WithValue(req.Context(), "user_id", tt.userID)
 			req = req.WithContext(ctx)
@@ -315,3 +476,566 @@ func TestListOrganizations(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestCreateShift(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          map[string]interface{}
+		mockSetup      func(*MockOrgRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Success - Split Shift",
+			input: map[string]interface{}{
+				"name":         "Lunch and dinner",
+				"timezone":     "Europe/Rome",
+				"working_days": []string{"TUE", "WED", "THU", "FRI", "SAT"},
+				"segments": []map[string]string{
+					{"start_time": "07:00", "end_time": "11:00"},
+					{"start_time": "16:00", "end_time": "20:00"},
+				},
+			},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("CreateShift", mock.Anything, mock.MatchedBy(func(s *domain.Shift) bool {
+					return s.OrgID == "org-123" && s.StartTime == "07:00" && s.EndTime == "20:00" && len(s.Segments) == 2
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Segments Spanning More Than A Day",
+			input: map[string]interface{}{
+				"name":         "Double",
+				"timezone":     "Europe/Rome",
+				"working_days": []string{"MON"},
+				"segments": []map[string]string{
+					{"start_time": "08:00", "end_time": "20:00"},
+					{"start_time": "21:00", "end_time": "09:00"},
+				},
+			},
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Single Segment",
+			input: map[string]interface{}{
+				"name":         "Morning",
+				"timezone":     "Europe/Rome",
+				"working_days": []string{"MON"},
+				"segments":     []map[string]string{{"start_time": "07:00", "end_time": "11:00"}},
+			},
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Neither Times Nor Segments",
+			input: map[string]interface{}{
+				"name":         "Morning",
+				"timezone":     "Europe/Rome",
+				"working_days": []string{"MON"},
+			},
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockOrgRepository)
+			tt.mockSetup(mockRepo)
+
+			handler := NewOrgHandler(service.NewOrgService(mockRepo, nil, nil))
+
+			r := chi.NewRouter()
+			r.Post("/organizations/{org_id}/shifts", handler.CreateShift)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/organizations/org-123/shifts", bytes.NewBuffer(body))
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateSite(t *testing.T) {
+	validSite := domain.Site{
+		Name:         "Vake Branch",
+		Address:      "1 Chavchavadze Ave, Tbilisi",
+		Latitude:     41.7089,
+		Longitude:    44.7667,
+		RadiusMeters: 150,
+		Timezone:     "Asia/Tbilisi",
+	}
+
+	tests := []struct {
+		name           string
+		userID         string
+		input          domain.Site
+		mockSetup      func(*MockOrgRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "Success - Manager",
+			userID: "user-manager",
+			input:  validSite,
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("CreateSite", mock.Anything, mock.MatchedBy(func(s *domain.Site) bool {
+					return s.OrgID == "org-123" && s.Name == "Vake Branch"
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "Forbidden - Employee",
+			userID: "user-employee",
+			input:  validSite,
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-employee").Return(&domain.OrganizationMember{Role: "EMPLOYEE"}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Duplicate Name",
+			userID: "user-manager",
+			input:  validSite,
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("CreateSite", mock.Anything, mock.Anything).Return(&domain.DuplicateError{Field: "name"})
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "Invalid Timezone",
+			userID: "user-manager",
+			input: domain.Site{
+				Name:         "Vake Branch",
+				Latitude:     41.7089,
+				Longitude:    44.7667,
+				RadiusMeters: 150,
+				Timezone:     "Mars/Olympus",
+			},
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Missing Radius",
+			userID: "user-manager",
+			input: domain.Site{
+				Name:      "Vake Branch",
+				Latitude:  41.7089,
+				Longitude: 44.7667,
+				Timezone:  "Asia/Tbilisi",
+			},
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Success - Boundary Without Radius",
+			userID: "user-manager",
+			input: domain.Site{
+				Name:      "Rustavi Plant",
+				Latitude:  41.5490,
+				Longitude: 45.0130,
+				Boundary: &domain.Boundary{
+					Type:        "MultiPolygon",
+					Coordinates: json.RawMessage(`[[[[45.0100,41.5470],[45.0160,41.5470],[45.0160,41.5510],[45.0100,41.5510],[45.0100,41.5470]]],[[[45.0200,41.5470],[45.0220,41.5470],[45.0220,41.5490],[45.0200,41.5470]]]]`),
+				},
+				Timezone: "Asia/Tbilisi",
+			},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("CreateSite", mock.Anything, mock.MatchedBy(func(s *domain.Site) bool {
+					return s.Boundary != nil && s.RadiusMeters == 0
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "Self-Intersecting Boundary",
+			userID: "user-manager",
+			input: domain.Site{
+				Name:      "Rustavi Plant",
+				Latitude:  41.5490,
+				Longitude: 45.0130,
+				Boundary: &domain.Boundary{
+					Type:        "Polygon",
+					Coordinates: json.RawMessage(`[[[45.0100,41.5470],[45.0160,41.5510],[45.0160,41.5470],[45.0100,41.5510],[45.0100,41.5470]]]`),
+				},
+				Timezone: "Asia/Tbilisi",
+			},
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Unclosed Boundary Ring",
+			userID: "user-manager",
+			input: domain.Site{
+				Name:      "Rustavi Plant",
+				Latitude:  41.5490,
+				Longitude: 45.0130,
+				Boundary: &domain.Boundary{
+					Type:        "Polygon",
+					Coordinates: json.RawMessage(`[[[45.0100,41.5470],[45.0160,41.5470],[45.0160,41.5510],[45.0100,41.5510]]]`),
+				},
+				Timezone: "Asia/Tbilisi",
+			},
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockOrgRepository)
+			tt.mockSetup(mockRepo)
+
+			handler := NewOrgHandler(service.NewOrgService(mockRepo, nil, nil))
+
+			r := chi.NewRouter()
+			r.Post("/organizations/{org_id}/sites", handler.CreateSite)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/organizations/org-123/sites", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", tt.userID)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteSite(t *testing.T) {
+	siteID := "123e4567-e89b-12d3-a456-426614174003"
+
+	tests := []struct {
+		name           string
+		siteID         string
+		mockSetup      func(*MockOrgRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "Success",
+			siteID: siteID,
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-owner").Return(&domain.OrganizationMember{Role: "OWNER"}, nil)
+				m.On("DeleteSite", mock.Anything, "org-123", siteID).Return(true, nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "Site Of Another Organization",
+			siteID: siteID,
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-owner").Return(&domain.OrganizationMember{Role: "OWNER"}, nil)
+				m.On("DeleteSite", mock.Anything, "org-123", siteID).Return(false, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid Site ID",
+			siteID:         "not-a-uuid",
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockOrgRepository)
+			tt.mockSetup(mockRepo)
+
+			handler := NewOrgHandler(service.NewOrgService(mockRepo, nil, nil))
+
+			r := chi.NewRouter()
+			r.Delete("/organizations/{org_id}/sites/{site_id}", handler.DeleteSite)
+
+			req, _ := http.NewRequest("DELETE", "/organizations/org-123/sites/"+tt.siteID, nil)
+			ctx := context.WithValue(req.Context(), "user_id", "user-owner")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateScheduleAssignment(t *testing.T) {
+	shiftID := "123e4567-e89b-12d3-a456-426614174010"
+	nightShift := &domain.Shift{ID: shiftID, OrgID: "org-123", Name: "Night", Timezone: "UTC"}
+	day := func(offset int) string { return time.Now().UTC().AddDate(0, 0, offset).Format("2006-01-02") }
+	date := func(offset int) *string { d := day(offset); return &d }
+
+	tests := []struct {
+		name           string
+		userID         string
+		input          domain.ScheduleAssignment
+		mockSetup      func(*MockOrgRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "Success - Ends Open-Ended Assignment",
+			userID: "user-manager",
+			input:  domain.ScheduleAssignment{ShiftID: &shiftID, EffectiveFrom: day(7), EffectiveTo: date(13)},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("GetMember", mock.Anything, "org-123", "user-employee").Return(&domain.OrganizationMember{Role: "EMPLOYEE"}, nil)
+				m.On("GetShiftByID", mock.Anything, shiftID).Return(nightShift, nil)
+				m.On("ListScheduleAssignments", mock.Anything, "org-123", "user-employee").Return([]*domain.ScheduleAssignment{
+					{ID: "assignment-1", EffectiveFrom: day(-30)},
+				}, nil)
+				m.On("SetScheduleAssignmentEnd", mock.Anything, "assignment-1", date(6)).Return(nil)
+				m.On("CreateScheduleAssignment", mock.Anything, mock.MatchedBy(func(a *domain.ScheduleAssignment) bool {
+					return a.OrgID == "org-123" && a.UserID == "user-employee" && a.EffectiveFrom == day(7)
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "Overlaps Bounded Assignment",
+			userID: "user-manager",
+			input:  domain.ScheduleAssignment{ShiftID: &shiftID, EffectiveFrom: day(7)},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("GetMember", mock.Anything, "org-123", "user-employee").Return(&domain.OrganizationMember{Role: "EMPLOYEE"}, nil)
+				m.On("GetShiftByID", mock.Anything, shiftID).Return(nightShift, nil)
+				m.On("ListScheduleAssignments", mock.Anything, "org-123", "user-employee").Return([]*domain.ScheduleAssignment{
+					{ID: "assignment-1", EffectiveFrom: day(10), EffectiveTo: date(20)},
+				}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "Past Effective Date",
+			userID: "user-manager",
+			input:  domain.ScheduleAssignment{ShiftID: &shiftID, EffectiveFrom: day(-1)},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("GetMember", mock.Anything, "org-123", "user-employee").Return(&domain.OrganizationMember{Role: "EMPLOYEE"}, nil)
+				m.On("GetShiftByID", mock.Anything, shiftID).Return(nightShift, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Shift Of Another Organization",
+			userID: "user-manager",
+			input:  domain.ScheduleAssignment{ShiftID: &shiftID, EffectiveFrom: day(7)},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("GetMember", mock.Anything, "org-123", "user-employee").Return(&domain.OrganizationMember{Role: "EMPLOYEE"}, nil)
+				m.On("GetShiftByID", mock.Anything, shiftID).Return(&domain.Shift{ID: shiftID, OrgID: "org-456", Timezone: "UTC"}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Forbidden - Employee",
+			userID: "user-employee",
+			input:  domain.ScheduleAssignment{ShiftID: &shiftID, EffectiveFrom: day(7)},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-employee").Return(&domain.OrganizationMember{Role: "EMPLOYEE"}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Invalid Effective Date",
+			userID:         "user-manager",
+			input:          domain.ScheduleAssignment{ShiftID: &shiftID, EffectiveFrom: "next monday"},
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockOrgRepository)
+			tt.mockSetup(mockRepo)
+
+			handler := NewOrgHandler(service.NewOrgService(mockRepo, nil, new(MockTransactionManager)))
+
+			r := chi.NewRouter()
+			r.Post("/organizations/{org_id}/members/{user_id}/schedule-assignments", handler.CreateScheduleAssignment)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/organizations/org-123/members/user-employee/schedule-assignments", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", tt.userID)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteScheduleAssignment(t *testing.T) {
+	assignmentID := "123e4567-e89b-12d3-a456-426614174011"
+	shiftID := "123e4567-e89b-12d3-a456-426614174010"
+	day := func(offset int) string { return time.Now().UTC().AddDate(0, 0, offset).Format("2006-01-02") }
+
+	tests := []struct {
+		name           string
+		assignment     *domain.ScheduleAssignment
+		expectedStatus int
+	}{
+		{
+			name:           "Success - Not Yet In Effect",
+			assignment:     &domain.ScheduleAssignment{ID: assignmentID, OrgID: "org-123", UserID: "user-employee", ShiftID: &shiftID, EffectiveFrom: day(3)},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Already In Effect",
+			assignment:     &domain.ScheduleAssignment{ID: assignmentID, OrgID: "org-123", UserID: "user-employee", ShiftID: &shiftID, EffectiveFrom: day(0)},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Assignment Of Another Member",
+			assignment:     &domain.ScheduleAssignment{ID: assignmentID, OrgID: "org-123", UserID: "user-other", ShiftID: &shiftID, EffectiveFrom: day(3)},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockOrgRepository)
+			mockRepo.On("GetMember", mock.Anything, "org-123", "user-owner").Return(&domain.OrganizationMember{Role: "OWNER"}, nil)
+			mockRepo.On("GetScheduleAssignmentByID", mock.Anything, assignmentID).Return(tt.assignment, nil)
+			mockRepo.On("GetShiftByID", mock.Anything, shiftID).Return(&domain.Shift{ID: shiftID, OrgID: "org-123", Timezone: "UTC"}, nil).Maybe()
+			if tt.expectedStatus == http.StatusNoContent {
+				mockRepo.On("DeleteScheduleAssignment", mock.Anything, assignmentID).Return(nil)
+			}
+
+			handler := NewOrgHandler(service.NewOrgService(mockRepo, nil, nil))
+
+			r := chi.NewRouter()
+			r.Delete("/organizations/{org_id}/members/{user_id}/schedule-assignments/{assignment_id}", handler.DeleteScheduleAssignment)
+
+			req, _ := http.NewRequest("DELETE", "/organizations/org-123/members/user-employee/schedule-assignments/"+assignmentID, nil)
+			ctx := context.WithValue(req.Context(), "user_id", "user-owner")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateRotation(t *testing.T) {
+	dayID := "123e4567-e89b-12d3-a456-426614174020"
+	nightID := "123e4567-e89b-12d3-a456-426614174021"
+	dayShift := &domain.Shift{ID: dayID, OrgID: "org-123", Name: "Day", Timezone: "Europe/Berlin"}
+	nightShift := &domain.Shift{ID: nightID, OrgID: "org-123", Name: "Night", Timezone: "Europe/Berlin"}
+
+	tests := []struct {
+		name           string
+		input          map[string]interface{}
+		mockSetup      func(*MockOrgRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Success - Days, Nights, Days Off",
+			input: map[string]interface{}{
+				"name":        "4 on 4 off",
+				"anchor_date": "2026-01-05",
+				"days":        []interface{}{dayID, dayID, nightID, nightID, nil, nil, nil, nil},
+			},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("GetShiftByID", mock.Anything, dayID).Return(dayShift, nil).Once()
+				m.On("GetShiftByID", mock.Anything, nightID).Return(nightShift, nil).Once()
+				m.On("CreateRotation", mock.Anything, mock.MatchedBy(func(r *domain.Rotation) bool {
+					return r.OrgID == "org-123" && len(r.Days) == 8 && r.Days[4] == nil && r.Timezone == "Europe/Berlin"
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Shifts In Different Timezones",
+			input: map[string]interface{}{
+				"name":        "Split",
+				"anchor_date": "2026-01-05",
+				"days":        []interface{}{dayID, nightID},
+			},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("GetShiftByID", mock.Anything, dayID).Return(dayShift, nil)
+				m.On("GetShiftByID", mock.Anything, nightID).Return(&domain.Shift{ID: nightID, OrgID: "org-123", Timezone: "UTC"}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Only Days Off",
+			input: map[string]interface{}{
+				"name":        "Holiday",
+				"anchor_date": "2026-01-05",
+				"days":        []interface{}{nil, nil},
+			},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Duplicate Name",
+			input: map[string]interface{}{
+				"name":        "4 on 4 off",
+				"anchor_date": "2026-01-05",
+				"days":        []interface{}{dayID, nil},
+			},
+			mockSetup: func(m *MockOrgRepository) {
+				m.On("GetMember", mock.Anything, "org-123", "user-manager").Return(&domain.OrganizationMember{Role: "MANAGER"}, nil)
+				m.On("GetShiftByID", mock.Anything, dayID).Return(dayShift, nil)
+				m.On("CreateRotation", mock.Anything, mock.Anything).Return(&domain.DuplicateError{Field: "name"})
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "Invalid Shift ID",
+			input: map[string]interface{}{
+				"name":        "Broken",
+				"anchor_date": "2026-01-05",
+				"days":        []interface{}{"day-shift", nil},
+			},
+			mockSetup:      func(m *MockOrgRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockOrgRepository)
+			tt.mockSetup(mockRepo)
+
+			handler := NewOrgHandler(service.NewOrgService(mockRepo, nil, nil))
+
+			r := chi.NewRouter()
+			r.Post("/organizations/{org_id}/rotations", handler.CreateRotation)
+
+			body, _ := json.Marshal(tt.input)
+			req, _ := http.NewRequest("POST", "/organizations/org-123/rotations", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", "user-manager")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
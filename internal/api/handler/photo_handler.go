@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+	"github.com/syst3mctl/check-in-api/internal/pkg/response"
+	"github.com/syst3mctl/check-in-api/internal/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// multipartOverhead allows for the multipart boundaries and headers around
+// the photo.
+const multipartOverhead = 64 << 10
+
+type PhotoHandler struct {
+	svc *service.PhotoService
+}
+
+func NewPhotoHandler(svc *service.PhotoService) *PhotoHandler {
+	return &PhotoHandler{svc: svc}
+}
+
+// UploadPhoto godoc
+// @Summary Attach a photo to a check-in
+// @Description Upload a selfie or site photo for one of your own attendance records as the multipart field "photo". JPEG, PNG and WebP are accepted, as detected from the file content. A record takes a single photo.
+// @Tags Attendance
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param attendance_id path string true "Attendance ID"
+// @Param photo formData file true "Photo"
+// @Success 201 {object} domain.Attendance
+// @Failure 400 {object} domain.ErrorResponse "missing or empty photo"
+// @Failure 404 {object} domain.ErrorResponse "attendance not found"
+// @Failure 409 {object} domain.ErrorResponse "attendance already has a photo"
+// @Failure 413 {object} domain.ErrorResponse "photo too large"
+// @Failure 415 {object} domain.ErrorResponse "unsupported photo type"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /attendance/{attendance_id}/photo [post]
+func (h *PhotoHandler) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	attendanceID := chi.URLParam(r, "attendance_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(attendanceID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "attendance not found")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.svc.MaxSize()+multipartOverhead)
+	mr, err := r.MultipartReader()
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "multipart request body required")
+		return
+	}
+	// Stream the photo part instead of buffering the whole form
+	var photo io.Reader
+	for photo == nil {
+		part, err := mr.NextPart()
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				response.WriteError(w, http.StatusRequestEntityTooLarge, "photo too large")
+				return
+			}
+			response.WriteError(w, http.StatusBadRequest, "photo is required")
+			return
+		}
+		if part.FormName() == "photo" {
+			photo = part
+		}
+	}
+
+	att, err := h.svc.UploadPhoto(r.Context(), userID, attendanceID, photo)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			response.WriteError(w, http.StatusRequestEntityTooLarge, "photo too large")
+			return
+		}
+		switch err.Error() {
+		case "attendance not found":
+			response.WriteError(w, http.StatusNotFound, err.Error())
+		case "attendance already has a photo":
+			response.WriteError(w, http.StatusConflict, err.Error())
+		case "photo too large":
+			response.WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+		case "unsupported photo type":
+			response.WriteError(w, http.StatusUnsupportedMediaType, err.Error())
+		case "photo is empty":
+			response.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusCreated, att)
+}
+
+// GetPhoto godoc
+// @Summary Download a check-in photo
+// @Description Download the photo attached to a member's attendance record (Owner/Manager only)
+// @Tags Attendance
+// @Security BearerAuth
+// @Produce image/jpeg,image/png,image/webp
+// @Param org_id path string true "Organization ID"
+// @Param attendance_id path string true "Attendance ID"
+// @Success 200 {file} file
+// @Failure 403 {object} domain.ErrorResponse "forbidden"
+// @Failure 404 {object} domain.ErrorResponse "attendance or photo not found"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /organizations/{org_id}/attendance/{attendance_id}/photo [get]
+func (h *PhotoHandler) GetPhoto(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "org_id")
+	attendanceID := chi.URLParam(r, "attendance_id")
+	userID := r.Context().Value("user_id").(string)
+	if !validator.IsValid(attendanceID, "uuid") {
+		response.WriteError(w, http.StatusNotFound, "attendance not found")
+		return
+	}
+
+	photo, att, err := h.svc.Photo(r.Context(), userID, orgID, attendanceID)
+	if err != nil {
+		switch err.Error() {
+		case "unauthorized":
+			response.WriteError(w, http.StatusForbidden, err.Error())
+		case "attendance not found", "photo not found":
+			response.WriteError(w, http.StatusNotFound, err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	defer photo.Close()
+
+	w.Header().Set("Content-Type", att.PhotoContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(att.PhotoSizeBytes, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, photo)
+}
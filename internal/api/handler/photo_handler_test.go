@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+)
+
+// memoryStorage is an in-memory port.FileStorage
+type memoryStorage struct {
+	files map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{files: map[string][]byte{}}
+}
+
+func (s *memoryStorage) Save(ctx context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.files[key] = data
+	return nil
+}
+
+func (s *memoryStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.files[key]
+	if !ok {
+		return nil, port.ErrFileNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) error {
+	delete(s.files, key)
+	return nil
+}
+
+func testPNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func multipartPhoto(t *testing.T, field string, data []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile(field, "photo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write(data)
+	mw.Close()
+	return &body, mw.FormDataContentType()
+}
+
+func TestUploadPhoto(t *testing.T) {
+	attendanceID := "123e4567-e89b-12d3-a456-426614174001"
+	own := func() *domain.Attendance {
+		return &domain.Attendance{ID: attendanceID, UserID: "user-123", OrgID: "org-1"}
+	}
+	pngData := testPNG(t)
+
+	tests := []struct {
+		name           string
+		field          string
+		data           []byte
+		maxSize        int64
+		mockSetup      func(*MockAttendanceRepository)
+		expectedStatus int
+		expectStored   bool
+	}{
+		{
+			name:  "Success",
+			field: "photo",
+			data:  pngData,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, attendanceID).Return(own(), nil)
+				m.On("SetAttendancePhoto", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+					return att.PhotoContentType == "image/png" && att.PhotoSizeBytes == int64(len(pngData)) && att.PhotoUploadedAt != nil
+				})).Return(true, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectStored:   true,
+		},
+		{
+			name:  "Content Type Is Sniffed",
+			field: "photo",
+			data:  []byte("#!/bin/sh\necho not a photo\n"),
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, attendanceID).Return(own(), nil)
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:    "Too Large",
+			field:   "photo",
+			data:    pngData,
+			maxSize: int64(len(pngData)) - 1,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, attendanceID).Return(own(), nil)
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:  "Already Has A Photo",
+			field: "photo",
+			data:  pngData,
+			mockSetup: func(m *MockAttendanceRepository) {
+				att := own()
+				att.PhotoKey = "attendance/org-1/existing.png"
+				m.On("GetAttendanceByID", mock.Anything, attendanceID).Return(att, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:  "Concurrent Upload Wins",
+			field: "photo",
+			data:  pngData,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, attendanceID).Return(own(), nil)
+				m.On("SetAttendancePhoto", mock.Anything, mock.Anything).Return(false, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:  "Another User's Attendance",
+			field: "photo",
+			data:  pngData,
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, attendanceID).Return(&domain.Attendance{ID: attendanceID, UserID: "user-999", OrgID: "org-1"}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Missing Photo Field",
+			field:          "file",
+			data:           pngData,
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+			storage := newMemoryStorage()
+			maxSize := tt.maxSize
+			if maxSize == 0 {
+				maxSize = 5 << 20
+			}
+
+			attSvc := service.NewAttendanceService(mockRepo, new(MockOrgRepository), new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewPhotoHandler(service.NewPhotoService(mockRepo, attSvc, storage, maxSize))
+
+			r := chi.NewRouter()
+			r.Post("/attendance/{attendance_id}/photo", handler.UploadPhoto)
+
+			body, contentType := multipartPhoto(t, tt.field, tt.data)
+			req, _ := http.NewRequest("POST", "/attendance/"+attendanceID+"/photo", body)
+			req.Header.Set("Content-Type", contentType)
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+			if tt.expectStored {
+				assert.Len(t, storage.files, 1)
+				for _, data := range storage.files {
+					assert.Equal(t, tt.data, data)
+				}
+			} else {
+				// Rejected uploads leave no file behind
+				assert.Empty(t, storage.files)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetPhoto(t *testing.T) {
+	attendanceID := "123e4567-e89b-12d3-a456-426614174001"
+	pngData := testPNG(t)
+	withPhoto := &domain.Attendance{ID: attendanceID, UserID: "user-456", OrgID: "org-1", PhotoKey: "attendance/org-1/p.png", PhotoContentType: "image/png", PhotoSizeBytes: int64(len(pngData))}
+
+	tests := []struct {
+		name           string
+		role           string
+		mockSetup      func(*MockAttendanceRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			role: "MANAGER",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, attendanceID).Return(withPhoto, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "No Photo",
+			role: "OWNER",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, attendanceID).Return(&domain.Attendance{ID: attendanceID, OrgID: "org-1"}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Other Organization",
+			role: "OWNER",
+			mockSetup: func(m *MockAttendanceRepository) {
+				m.On("GetAttendanceByID", mock.Anything, attendanceID).Return(&domain.Attendance{ID: attendanceID, OrgID: "org-2", PhotoKey: "attendance/org-2/p.png"}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Forbidden - Employee",
+			role:           "EMPLOYEE",
+			mockSetup:      func(m *MockAttendanceRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			mockOrgRepo.On("GetMember", mock.Anything, "org-1", "user-123").Return(&domain.OrganizationMember{Role: tt.role}, nil)
+			storage := newMemoryStorage()
+			storage.files[withPhoto.PhotoKey] = pngData
+
+			attSvc := service.NewAttendanceService(mockRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			handler := NewPhotoHandler(service.NewPhotoService(mockRepo, attSvc, storage, 5<<20))
+
+			r := chi.NewRouter()
+			r.Get("/organizations/{org_id}/attendance/{attendance_id}/photo", handler.GetPhoto)
+
+			req, _ := http.NewRequest("GET", "/organizations/org-1/attendance/"+attendanceID+"/photo", nil)
+			ctx := context.WithValue(req.Context(), "user_id", "user-123")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if rr.Code == http.StatusOK {
+				assert.Equal(t, "image/png", rr.Header().Get("Content-Type"))
+				assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+				assert.Equal(t, pngData, rr.Body.Bytes())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
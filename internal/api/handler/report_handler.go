@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/syst3mctl/check-in-api/internal/core/service"
 	"github.com/syst3mctl/check-in-api/internal/pkg/response"
@@ -26,12 +27,39 @@ func NewReportHandler(svc *service.ReportService) *ReportHandler {
 // @Produce json
 // @Param org_id path string true "Organization ID"
 // @Param group_id path string true "Group ID"
+// @Param from query string false "First shift date (YYYY-MM-DD), defaults to 29 days before to"
+// @Param to query string false "Last shift date (YYYY-MM-DD), defaults to today"
 // @Success 200 {object} domain.GroupPerformanceReport
+// @Failure 400 {object} domain.ErrorResponse "invalid date range"
 // @Failure 500 {object} domain.ErrorResponse "internal server error"
 // @Router /organizations/{org_id}/reports/groups/{group_id} [get]
 func (h *ReportHandler) GetGroupPerformance(w http.ResponseWriter, r *http.Request) {
 	groupID := chi.URLParam(r, "group_id")
-	report, err := h.svc.GetGroupPerformance(r.Context(), groupID)
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if v := r.URL.Query().Get("to"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "to must be a date in YYYY-MM-DD format")
+			return
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -29)
+	if v := r.URL.Query().Get("from"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "from must be a date in YYYY-MM-DD format")
+			return
+		}
+		from = parsed
+	}
+	if from.After(to) || to.Sub(from) > 366*24*time.Hour {
+		response.WriteError(w, http.StatusBadRequest, "from must not be after to, and the range must not exceed one year")
+		return
+	}
+
+	report, err := h.svc.GetGroupPerformance(r.Context(), groupID, from, to)
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -19,34 +20,78 @@ type MockReportRepository struct {
 	mock.Mock
 }
 
-func (m *MockReportRepository) GetGroupPerformance(ctx context.Context, groupID string) (*domain.GroupPerformanceReport, error) {
-	args := m.Called(ctx, groupID)
+func (m *MockReportRepository) GetGroupPerformance(ctx context.Context, groupID, from, to string) (*domain.GroupPerformanceReport, error) {
+	args := m.Called(ctx, groupID, from, to)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.GroupPerformanceReport), args.Error(1)
 }
 
+func (m *MockReportRepository) GetGroupSchedule(ctx context.Context, groupID string) (*domain.Schedule, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Schedule), args.Error(1)
+}
+
+func (m *MockReportRepository) CountGroupMembers(ctx context.Context, groupID string) (int, error) {
+	args := m.Called(ctx, groupID)
+	return args.Int(0), args.Error(1)
+}
+
 func TestGetGroupPerformance(t *testing.T) {
+	weekdayShift := &domain.Shift{
+		Name:        "Night",
+		StartTime:   "22:00",
+		EndTime:     "06:00",
+		Timezone:    "Europe/Berlin",
+		WorkingDays: []string{"MON", "TUE", "WED", "THU", "FRI"},
+	}
+
 	tests := []struct {
 		name           string
 		groupID        string
+		query          string
 		mockSetup      func(*MockReportRepository)
 		expectedStatus int
+		expectedRate   string
 	}{
 		{
 			name:    "Success",
 			groupID: "group-123",
 			mockSetup: func(m *MockReportRepository) {
-				m.On("GetGroupPerformance", mock.Anything, "group-123").Return(&domain.GroupPerformanceReport{GroupName: "Test Group"}, nil)
+				m.On("GetGroupPerformance", mock.Anything, "group-123", mock.Anything, mock.Anything).Return(&domain.GroupPerformanceReport{GroupName: "Test Group"}, nil)
+				m.On("GetGroupSchedule", mock.Anything, "group-123").Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedRate:   "N/A",
+		},
+		{
+			name:    "Attendance Rate From Shift Instances",
+			groupID: "group-123",
+			query:   "?from=2026-03-23&to=2026-03-29", // Mon-Sun, spans the DST change
+			mockSetup: func(m *MockReportRepository) {
+				m.On("GetGroupPerformance", mock.Anything, "group-123", "2026-03-23", "2026-03-29").Return(&domain.GroupPerformanceReport{GroupName: "Test Group", AttendedShifts: 8}, nil)
+				m.On("GetGroupSchedule", mock.Anything, "group-123").Return(&domain.Schedule{Shift: weekdayShift}, nil)
+				m.On("CountGroupMembers", mock.Anything, "group-123").Return(2, nil)
 			},
 			expectedStatus: http.StatusOK,
+			expectedRate:   "80.0%",
+		},
+		{
+			name:           "Invalid Range",
+			groupID:        "group-123",
+			query:          "?from=2026-03-29&to=2026-03-23",
+			mockSetup:      func(m *MockReportRepository) {},
+			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:    "Error",
 			groupID: "group-error",
 			mockSetup: func(m *MockReportRepository) {
-				m.On("GetGroupPerformance", mock.Anything, "group-error").Return(nil, errors.New("db error"))
+				m.On("GetGroupPerformance", mock.Anything, "group-error", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -63,12 +108,17 @@ func TestGetGroupPerformance(t *testing.T) {
 			r := chi.NewRouter()
 			r.Get("/organizations/{org_id}/reports/groups/{group_id}", handler.GetGroupPerformance)
 
-			req, _ := http.NewRequest("GET", "/organizations/org-1/reports/groups/"+tt.groupID, nil)
+			req, _ := http.NewRequest("GET", "/organizations/org-1/reports/groups/"+tt.groupID+tt.query, nil)
 			rr := httptest.NewRecorder()
 
 			r.ServeHTTP(rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedRate != "" {
+				var report domain.GroupPerformanceReport
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+				assert.Equal(t, tt.expectedRate, report.AttendanceRate)
+			}
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+	"github.com/syst3mctl/check-in-api/internal/pkg/response"
+	"github.com/syst3mctl/check-in-api/internal/pkg/validator"
+)
+
+type SyncHandler struct {
+	svc *service.SyncService
+}
+
+func NewSyncHandler(svc *service.SyncService) *SyncHandler {
+	return &SyncHandler{svc: svc}
+}
+
+// SyncEvents godoc
+// @Summary Sync offline punches
+// @Description Apply check-ins and check-outs the device queued while offline, oldest first. Each event is accepted or rejected on its own; accepted events are marked offline, and events captured long ago or from a skewed device clock are flagged. Retried events return their original result.
+// @Tags Attendance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body domain.SyncRequest true "Queued events"
+// @Param Idempotency-Key header string false "Key for safely retrying the request"
+// @Success 200 {object} domain.SyncResponse
+// @Failure 400 {object} domain.ErrorResponse "invalid request body or validation errors"
+// @Failure 500 {object} domain.ErrorResponse "internal server error"
+// @Router /attendance/sync [post]
+func (h *SyncHandler) SyncEvents(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+	var req domain.SyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errResp := validator.ValidateStruct(&req); errResp != nil {
+		response.WriteValidationError(w, errResp)
+		return
+	}
+
+	results, err := h.svc.SyncEvents(r.Context(), userID, req.Events)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, domain.SyncResponse{Results: results})
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+)
+
+// MockSyncRepository is a mock implementation of port.SyncRepository
+type MockSyncRepository struct {
+	mock.Mock
+}
+
+func (m *MockSyncRepository) CreateOfflineEvent(ctx context.Context, event *domain.OfflineEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockSyncRepository) GetOfflineEvent(ctx context.Context, userID, clientEventID string) (*domain.OfflineEvent, error) {
+	args := m.Called(ctx, userID, clientEventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OfflineEvent), args.Error(1)
+}
+
+func TestSyncEvents(t *testing.T) {
+	validUserID := "user-123"
+	orgID := "123e4567-e89b-12d3-a456-426614174000"
+	lat, long := 41.7151, 44.8271
+	checkIn := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+	checkOut := checkIn.Add(2 * time.Hour)
+
+	event := func(id, eventType string, at time.Time) map[string]interface{} {
+		ev := map[string]interface{}{"client_event_id": id, "type": eventType, "captured_at": at}
+		if eventType == domain.SyncEventCheckIn {
+			ev["organization_id"] = orgID
+			ev["latitude"] = lat
+			ev["longitude"] = long
+		}
+		return ev
+	}
+	orgOff := func(m *MockOrgRepository) {
+		m.On("GetOrganizationByID", mock.Anything, orgID).Return(&domain.Organization{ID: orgID, GeofenceMode: domain.GeofenceModeOff}, nil)
+	}
+
+	tests := []struct {
+		name             string
+		events           []map[string]interface{}
+		mockSetup        func(*MockSyncRepository, *MockAttendanceRepository)
+		orgSetup         func(*MockOrgRepository)
+		expectedStatus   int
+		expectedStatuses []string
+		expectedFlagged  []bool
+	}{
+		{
+			name:   "Check-in And Check-out",
+			events: []map[string]interface{}{event("ev-1", domain.SyncEventCheckIn, checkIn), event("ev-2", domain.SyncEventCheckOut, checkOut)},
+			mockSetup: func(m *MockSyncRepository, a *MockAttendanceRepository) {
+				m.On("GetOfflineEvent", mock.Anything, validUserID, "ev-1").Return(nil, nil)
+				m.On("GetOfflineEvent", mock.Anything, validUserID, "ev-2").Return(nil, nil)
+				a.On("ListOpenAttendance", mock.Anything, validUserID, orgID).Return(nil, nil).Once()
+				a.On("GetMemberGroup", mock.Anything, orgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				a.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+					return att.Offline && att.CheckInTime.Equal(checkIn) && !att.Flagged
+				})).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Attendance).ID = "att-1"
+				})
+				a.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1", OrgID: orgID, CheckInTime: checkIn}}, nil)
+				a.On("EndBreak", mock.Anything, "att-1", mock.MatchedBy(checkOut.Equal)).Return(nil, nil)
+				a.On("UpdateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+					return att.CheckOutOffline && att.CheckOutTime.Equal(checkOut)
+				})).Return(nil)
+				m.On("CreateOfflineEvent", mock.Anything, mock.MatchedBy(func(e *domain.OfflineEvent) bool {
+					return e.Status == domain.SyncStatusAccepted && *e.AttendanceID == "att-1" && e.OrgID == orgID
+				})).Return(nil).Twice()
+			},
+			orgSetup:         orgOff,
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []string{domain.SyncStatusAccepted, domain.SyncStatusAccepted},
+			expectedFlagged:  []bool{false, false},
+		},
+		{
+			name: "Old Event From Skewed Clock Is Flagged",
+			events: []map[string]interface{}{func() map[string]interface{} {
+				// The device clock runs ten minutes fast
+				ev := event("ev-1", domain.SyncEventCheckIn, checkIn.Add(-96*time.Hour+10*time.Minute))
+				ev["clock_offset_seconds"] = 600
+				return ev
+			}()},
+			mockSetup: func(m *MockSyncRepository, a *MockAttendanceRepository) {
+				m.On("GetOfflineEvent", mock.Anything, validUserID, "ev-1").Return(nil, nil)
+				a.On("ListOpenAttendance", mock.Anything, validUserID, orgID).Return(nil, nil)
+				a.On("GetMemberGroup", mock.Anything, orgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				a.On("CreateAttendance", mock.Anything, mock.MatchedBy(func(att *domain.Attendance) bool {
+					return att.CheckInTime.Equal(checkIn.Add(-96*time.Hour)) && att.Flagged
+				})).Return(nil)
+				m.On("CreateOfflineEvent", mock.Anything, mock.Anything).Return(nil)
+			},
+			orgSetup:         orgOff,
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []string{domain.SyncStatusAccepted},
+			expectedFlagged:  []bool{true},
+		},
+		{
+			name: "Check-in Outside Geofence Records Violation",
+			events: []map[string]interface{}{func() map[string]interface{} {
+				ev := event("ev-1", domain.SyncEventCheckIn, checkIn)
+				ev["latitude"] = lat + 0.01 // ~1.1km north of the default location
+				return ev
+			}()},
+			mockSetup: func(m *MockSyncRepository, a *MockAttendanceRepository) {
+				m.On("GetOfflineEvent", mock.Anything, validUserID, "ev-1").Return(nil, nil)
+				a.On("ListOpenAttendance", mock.Anything, validUserID, orgID).Return(nil, nil)
+				a.On("GetMemberGroup", mock.Anything, orgID, validUserID, mock.Anything).Return(&domain.Group{ID: "group-1"}, nil, nil)
+				// Written after the sync transaction, whose rollback would discard it
+				a.On("CreateCheckInViolation", mock.MatchedBy(func(ctx context.Context) bool {
+					return ctx.Value(inTxKey{}) == nil
+				}), mock.MatchedBy(func(v *domain.CheckInViolation) bool {
+					return v.Kind == domain.ViolationKindGeofence && v.UserID == validUserID && v.OrgID == orgID
+				})).Return(nil)
+			},
+			orgSetup: func(m *MockOrgRepository) {
+				m.On("GetOrganizationByID", mock.Anything, orgID).Return(geofencedOrg(domain.GeofenceModeReject), nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []string{domain.SyncStatusRejected},
+		},
+		{
+			name:   "Already Synced",
+			events: []map[string]interface{}{event("ev-1", domain.SyncEventCheckIn, checkIn)},
+			mockSetup: func(m *MockSyncRepository, a *MockAttendanceRepository) {
+				m.On("GetOfflineEvent", mock.Anything, validUserID, "ev-1").Return(&domain.OfflineEvent{ClientEventID: "ev-1", Status: domain.SyncStatusAccepted, EventTime: checkIn}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []string{domain.SyncStatusAccepted},
+		},
+		{
+			name:   "Out Of Order And Future Events",
+			events: []map[string]interface{}{event("ev-1", domain.SyncEventCheckOut, time.Now().Add(time.Hour)), event("ev-2", domain.SyncEventCheckOut, checkOut)},
+			mockSetup: func(m *MockSyncRepository, a *MockAttendanceRepository) {
+				m.On("GetOfflineEvent", mock.Anything, validUserID, "ev-1").Return(nil, nil)
+				m.On("GetOfflineEvent", mock.Anything, validUserID, "ev-2").Return(nil, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []string{domain.SyncStatusRejected, domain.SyncStatusRejected},
+		},
+		{
+			name:   "Check-out Before Check-in",
+			events: []map[string]interface{}{event("ev-1", domain.SyncEventCheckOut, checkIn.Add(-time.Minute))},
+			mockSetup: func(m *MockSyncRepository, a *MockAttendanceRepository) {
+				m.On("GetOfflineEvent", mock.Anything, validUserID, "ev-1").Return(nil, nil)
+				a.On("ListOpenAttendance", mock.Anything, validUserID, "").Return([]*domain.Attendance{{ID: "att-1", OrgID: orgID, CheckInTime: checkIn}}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []string{domain.SyncStatusRejected},
+		},
+		{
+			name:           "Check-in Without Location",
+			events:         []map[string]interface{}{{"client_event_id": "ev-1", "type": "CHECK_IN", "organization_id": orgID, "captured_at": checkIn}},
+			mockSetup:      func(m *MockSyncRepository, a *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "No Events",
+			events:         []map[string]interface{}{},
+			mockSetup:      func(m *MockSyncRepository, a *MockAttendanceRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSyncRepository)
+			mockAttRepo := new(MockAttendanceRepository)
+			tt.mockSetup(mockRepo, mockAttRepo)
+			mockOrgRepo := new(MockOrgRepository)
+			if tt.orgSetup != nil {
+				tt.orgSetup(mockOrgRepo)
+			}
+			mockOrgRepo.On("ListSites", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+			attSvc := service.NewAttendanceService(mockAttRepo, mockOrgRepo, new(MockTransactionManager), service.RiskPolicy{})
+			svc := service.NewSyncService(mockRepo, attSvc, new(MockTransactionManager), 72*time.Hour, 5*time.Minute)
+			handler := NewSyncHandler(svc)
+
+			body, _ := json.Marshal(map[string]interface{}{"events": tt.events})
+			req, _ := http.NewRequest("POST", "/attendance/sync", bytes.NewBuffer(body))
+			ctx := context.WithValue(req.Context(), "user_id", validUserID)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler.SyncEvents(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatuses != nil {
+				var resp domain.SyncResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				if assert.Len(t, resp.Results, len(tt.expectedStatuses)) {
+					for i, status := range tt.expectedStatuses {
+						assert.Equal(t, status, resp.Results[i].Status, resp.Results[i].Error)
+						if tt.expectedFlagged != nil {
+							assert.Equal(t, tt.expectedFlagged[i], resp.Results[i].Flagged, resp.Results[i].FlagReason)
+						}
+					}
+				}
+			}
+			mockRepo.AssertExpectations(t)
+			mockAttRepo.AssertExpectations(t)
+			mockOrgRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIPMiddleware stores the client's address in the request context
+// under "client_ip". X-Forwarded-For is only believed for hops added by
+// trusted proxies, so clients cannot spoof their address.
+type ClientIPMiddleware struct {
+	trusted []netip.Prefix
+}
+
+// NewClientIPMiddleware takes the trusted proxies as CIDR ranges or single
+// addresses.
+func NewClientIPMiddleware(trustedProxies []string) (*ClientIPMiddleware, error) {
+	m := &ClientIPMiddleware{}
+	for _, proxy := range trustedProxies {
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(proxy)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		}
+		m.trusted = append(m.trusted, prefix.Masked())
+	}
+	return m, nil
+}
+
+func (m *ClientIPMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), "client_ip", m.clientIP(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// clientIP walks X-Forwarded-For back from the connecting peer while the
+// hops are trusted proxies; the first untrusted hop is the client.
+func (m *ClientIPMiddleware) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	addr = addr.Unmap()
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0 && m.isTrusted(addr); i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break // Keep the last address we can vouch for
+		}
+		addr = hop.Unmap()
+	}
+	return addr.String()
+}
+
+func (m *ClientIPMiddleware) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range m.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
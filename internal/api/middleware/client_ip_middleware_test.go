@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIPMiddleware(t *testing.T) {
+	m, err := NewClientIPMiddleware([]string{"10.0.0.0/8", "192.168.1.5"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		expectedIP string
+	}{
+		{
+			name:       "Direct Connection",
+			remoteAddr: "203.0.113.7:5000",
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:       "Forwarded Header From Untrusted Peer Is Ignored",
+			remoteAddr: "203.0.113.7:5000",
+			forwarded:  []string{"10.1.2.3"},
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:       "Trusted Proxy",
+			remoteAddr: "10.0.0.2:5000",
+			forwarded:  []string{"198.51.100.4"},
+			expectedIP: "198.51.100.4",
+		},
+		{
+			name:       "Chain Of Trusted Proxies",
+			remoteAddr: "10.0.0.2:5000",
+			forwarded:  []string{"198.51.100.4, 192.168.1.5", "10.0.0.9"},
+			expectedIP: "198.51.100.4",
+		},
+		{
+			name:       "Spoofed Entry Before Untrusted Hop",
+			remoteAddr: "10.0.0.2:5000",
+			forwarded:  []string{"10.9.9.9, 198.51.100.4"},
+			expectedIP: "198.51.100.4",
+		},
+		{
+			name:       "Malformed Hop",
+			remoteAddr: "10.0.0.2:5000",
+			forwarded:  []string{"not-an-ip"},
+			expectedIP: "10.0.0.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := m.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = r.Context().Value("client_ip").(string)
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, header := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expectedIP, got)
+		})
+	}
+}
+
+func TestNewClientIPMiddlewareInvalidProxy(t *testing.T) {
+	_, err := NewClientIPMiddleware([]string{"not-a-network"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+	"github.com/syst3mctl/check-in-api/internal/pkg/logger"
+	"github.com/syst3mctl/check-in-api/internal/pkg/response"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// maxIdempotentBodySize caps the request body buffered for hashing.
+const maxIdempotentBodySize = 1 << 20
+
+type IdempotencyMiddleware struct {
+	svc *service.IdempotencyService
+}
+
+func NewIdempotencyMiddleware(svc *service.IdempotencyService) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{svc: svc}
+}
+
+// Handle makes requests carrying an Idempotency-Key header safe to retry. The
+// first request with a key runs normally and its response is stored; a retry
+// with the same key and payload gets the stored response back. Requests
+// without the header are passed through. Must run after AuthMiddleware, as
+// keys are scoped to the user.
+func (m *IdempotencyMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response.WriteError(w, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+		userID := r.Context().Value("user_id").(string)
+
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					response.WriteError(w, http.StatusRequestEntityTooLarge, "request body too large")
+					return
+				}
+				response.WriteError(w, http.StatusBadRequest, "invalid request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		hash := sha256.New()
+		hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+		hash.Write(body)
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+
+		stored, err := m.svc.Begin(r.Context(), userID, key, requestHash)
+		if err != nil {
+			switch err.Error() {
+			case "idempotency key was used with a different request":
+				response.WriteError(w, http.StatusUnprocessableEntity, err.Error())
+			case "a request with this idempotency key is in progress":
+				response.WriteError(w, http.StatusConflict, err.Error())
+			default:
+				response.WriteError(w, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+		if stored != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(*stored.ResponseStatus)
+			w.Write(stored.ResponseBody)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// Store the outcome even if the client has gone away, since that is
+		// exactly when it will retry.
+		ctx := context.WithoutCancel(r.Context())
+		if rec.status >= http.StatusInternalServerError {
+			err = m.svc.Release(ctx, userID, key)
+		} else {
+			err = m.svc.Complete(ctx, userID, key, requestHash, rec.status, rec.body.Bytes())
+		}
+		if err != nil {
+			logger.Error("Failed to save idempotency key", "error", err)
+		}
+	})
+}
+
+// responseRecorder passes the response through while keeping a copy of the
+// status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
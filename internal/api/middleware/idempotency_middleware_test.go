@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+)
+
+// MockIdempotencyRepository is a mock implementation of port.IdempotencyRepository
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, staleBefore time.Time) error {
+	args := m.Called(ctx, key, staleBefore)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) GetIdempotencyKey(ctx context.Context, userID, key string) (*domain.IdempotencyKey, error) {
+	args := m.Called(ctx, userID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IdempotencyKey), args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, userID, key string) error {
+	args := m.Called(ctx, userID, key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	const (
+		userID = "user-123"
+		key    = "retry-key-1"
+		body   = `{"organization_id":"123e4567-e89b-12d3-a456-426614174000"}`
+	)
+	sum := sha256.Sum256([]byte("POST /attendance/check-in\n" + body))
+	requestHash := hex.EncodeToString(sum[:])
+	created := http.StatusCreated
+
+	tests := []struct {
+		name           string
+		key            string
+		requestBody    string // Defaults to body
+		handlerStatus  int
+		mockSetup      func(*MockIdempotencyRepository)
+		expectedStatus int
+		expectedBody   string
+		expectHandler  bool
+	}{
+		{
+			name:           "No Key",
+			handlerStatus:  http.StatusOK,
+			mockSetup:      func(m *MockIdempotencyRepository) {},
+			expectedStatus: http.StatusOK,
+			expectHandler:  true,
+		},
+		{
+			name:          "First Request",
+			key:           key,
+			handlerStatus: http.StatusCreated,
+			mockSetup: func(m *MockIdempotencyRepository) {
+				m.On("CreateIdempotencyKey", mock.Anything, mock.MatchedBy(func(k *domain.IdempotencyKey) bool {
+					return k.UserID == userID && k.Key == key && k.RequestHash == requestHash && k.ExpiresAt.Sub(k.CreatedAt) == time.Hour
+				}), mock.Anything).Return(nil)
+				m.On("CompleteIdempotencyKey", mock.Anything, mock.MatchedBy(func(k *domain.IdempotencyKey) bool {
+					return *k.ResponseStatus == http.StatusCreated && string(k.ResponseBody) == `{"id":"att-1"}`
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"att-1"}`,
+			expectHandler:  true,
+		},
+		{
+			name: "Replay",
+			key:  key,
+			mockSetup: func(m *MockIdempotencyRepository) {
+				m.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.Anything).Return(&domain.DuplicateError{Field: "idempotency key"})
+				m.On("GetIdempotencyKey", mock.Anything, userID, key).Return(&domain.IdempotencyKey{RequestHash: requestHash, ResponseStatus: &created, ResponseBody: []byte(`{"id":"att-1"}`)}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"att-1"}`,
+		},
+		{
+			name: "Different Payload",
+			key:  key,
+			mockSetup: func(m *MockIdempotencyRepository) {
+				m.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.Anything).Return(&domain.DuplicateError{Field: "idempotency key"})
+				m.On("GetIdempotencyKey", mock.Anything, userID, key).Return(&domain.IdempotencyKey{RequestHash: "other", ResponseStatus: &created}, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "In Progress",
+			key:  key,
+			mockSetup: func(m *MockIdempotencyRepository) {
+				m.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.Anything).Return(&domain.DuplicateError{Field: "idempotency key"})
+				m.On("GetIdempotencyKey", mock.Anything, userID, key).Return(&domain.IdempotencyKey{RequestHash: requestHash}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:          "Server Error Releases Key",
+			key:           key,
+			handlerStatus: http.StatusInternalServerError,
+			mockSetup: func(m *MockIdempotencyRepository) {
+				m.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				m.On("DeleteIdempotencyKey", mock.Anything, userID, key).Return(nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectHandler:  true,
+		},
+		{
+			name:           "Key Too Long",
+			key:            strings.Repeat("k", 256),
+			mockSetup:      func(m *MockIdempotencyRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Body Too Large",
+			key:            key,
+			requestBody:    `{"note":"` + strings.Repeat("x", 1<<20) + `"}`,
+			mockSetup:      func(m *MockIdempotencyRepository) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIdempotencyRepository)
+			tt.mockSetup(mockRepo)
+
+			mw := NewIdempotencyMiddleware(service.NewIdempotencyService(mockRepo, time.Hour))
+			handlerCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerCalled = true
+				w.WriteHeader(tt.handlerStatus)
+				w.Write([]byte(`{"id":"att-1"}`))
+			})
+
+			requestBody := body
+			if tt.requestBody != "" {
+				requestBody = tt.requestBody
+			}
+			req, _ := http.NewRequest("POST", "/attendance/check-in", strings.NewReader(requestBody))
+			if tt.key != "" {
+				req.Header.Set("Idempotency-Key", tt.key)
+			}
+			ctx := context.WithValue(req.Context(), "user_id", userID)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			mw.Handle(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectHandler, handlerCalled)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, rr.Body.String())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/syst3mctl/check-in-api/internal/core/service"
+	"github.com/syst3mctl/check-in-api/internal/pkg/response"
+)
+
+// KioskMiddleware authenticates shared kiosk devices by the token issued at
+// registration, sent as "Authorization: Kiosk <token>". The kiosk is stored
+// in the request context under "kiosk".
+type KioskMiddleware struct {
+	svc *service.KioskService
+}
+
+func NewKioskMiddleware(svc *service.KioskService) *KioskMiddleware {
+	return &KioskMiddleware{svc: svc}
+}
+
+func (m *KioskMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			response.WriteError(w, http.StatusUnauthorized, "authorization header required")
+			return
+		}
+
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Kiosk" {
+			response.WriteError(w, http.StatusUnauthorized, "invalid authorization header format")
+			return
+		}
+
+		kiosk, err := m.svc.AuthenticateKiosk(r.Context(), parts[1])
+		if err != nil {
+			if err.Error() == "invalid kiosk token" {
+				response.WriteError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+			response.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "kiosk", kiosk)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -10,21 +10,31 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
-func New(authHandler *handler.AuthHandler, userHandler *handler.UserHandler, orgHandler *handler.OrgHandler, attendanceHandler *handler.AttendanceHandler, reportHandler *handler.ReportHandler, authMiddleware *middleware.AuthMiddleware) *chi.Mux {
+func New(authHandler *handler.AuthHandler, userHandler *handler.UserHandler, orgHandler *handler.OrgHandler, attendanceHandler *handler.AttendanceHandler, reportHandler *handler.ReportHandler, correctionHandler *handler.CorrectionHandler, syncHandler *handler.SyncHandler, kioskHandler *handler.KioskHandler, photoHandler *handler.PhotoHandler, authMiddleware *middleware.AuthMiddleware, idempotencyMiddleware *middleware.IdempotencyMiddleware, kioskMiddleware *middleware.KioskMiddleware, clientIPMiddleware *middleware.ClientIPMiddleware) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(chiMiddleware.Logger)
 	r.Use(chiMiddleware.Recoverer)
+	r.Use(clientIPMiddleware.Handle)
 
 	r.Post("/auth/register", authHandler.Register)
 	r.Post("/auth/login", authHandler.Login)
 	r.Post("/auth/refresh", authHandler.RefreshToken)
 
+	// Kiosk devices authenticate with their own token, not a user's JWT
+	r.Group(func(r chi.Router) {
+		r.Use(kioskMiddleware.Handle)
+
+		r.Post("/kiosk/check-in", kioskHandler.CheckIn)
+		r.Post("/kiosk/check-out", kioskHandler.CheckOut)
+	})
+
 	r.Group(func(r chi.Router) {
 		r.Use(authMiddleware.Handle)
 
 		// User
 		r.Get("/me", userHandler.GetMe)
+		r.Get("/me/attendance", attendanceHandler.ListMyAttendance)
 		r.Put("/update-profile", userHandler.UpdateProfile)
 		r.Post("/logout", userHandler.Logout)
 
@@ -42,12 +52,61 @@ func New(authHandler *handler.AuthHandler, userHandler *handler.UserHandler, org
 		r.Put("/organizations/{org_id}/employees/{user_id}", orgHandler.UpdateEmployee)
 		r.Delete("/organizations/{org_id}/employees/{user_id}", orgHandler.RemoveEmployee)
 
+		// Sites
+		r.Post("/organizations/{org_id}/sites", orgHandler.CreateSite)
+		r.Get("/organizations/{org_id}/sites", orgHandler.ListSites)
+		r.Get("/organizations/{org_id}/sites/{site_id}", orgHandler.GetSite)
+		r.Put("/organizations/{org_id}/sites/{site_id}", orgHandler.UpdateSite)
+		r.Delete("/organizations/{org_id}/sites/{site_id}", orgHandler.DeleteSite)
+
+		// Schedule assignments
+		r.Post("/organizations/{org_id}/members/{user_id}/schedule-assignments", orgHandler.CreateScheduleAssignment)
+		r.Get("/organizations/{org_id}/members/{user_id}/schedule-assignments", orgHandler.ListScheduleAssignments)
+		r.Post("/organizations/{org_id}/members/{user_id}/schedule-assignments/{assignment_id}/end", orgHandler.EndScheduleAssignment)
+		r.Delete("/organizations/{org_id}/members/{user_id}/schedule-assignments/{assignment_id}", orgHandler.DeleteScheduleAssignment)
+
+		// Rotations
+		r.Post("/organizations/{org_id}/rotations", orgHandler.CreateRotation)
+		r.Get("/organizations/{org_id}/rotations", orgHandler.ListRotations)
+		r.Get("/organizations/{org_id}/rotations/{rotation_id}", orgHandler.GetRotation)
+
 		// Tasks
 		r.Post("/organizations/{org_id}/tasks", attendanceHandler.CreateTask)
 
+		// Checkpoints
+		r.Post("/organizations/{org_id}/checkpoints", attendanceHandler.CreateCheckpoint)
+		r.Get("/organizations/{org_id}/checkpoints", attendanceHandler.ListCheckpoints)
+		r.Get("/organizations/{org_id}/checkpoints/{checkpoint_id}/token", attendanceHandler.GetCheckpointToken)
+
+		// Kiosks
+		r.Post("/organizations/{org_id}/kiosks", kioskHandler.RegisterKiosk)
+		r.Get("/organizations/{org_id}/kiosks", kioskHandler.ListKiosks)
+		r.Delete("/organizations/{org_id}/kiosks/{kiosk_id}", kioskHandler.RevokeKiosk)
+		r.Put("/organizations/{org_id}/members/{user_id}/kiosk-credentials", kioskHandler.SetMemberCredentials)
+
 		// Attendance
-		r.Post("/attendance/check-in", attendanceHandler.CheckIn)
-		r.Post("/attendance/check-out", attendanceHandler.CheckOut)
+		r.Get("/organizations/{org_id}/attendance", attendanceHandler.ListTeamAttendance)
+		r.Get("/organizations/{org_id}/check-in-violations", attendanceHandler.ListCheckInViolations)
+		r.Get("/organizations/{org_id}/attendance/{attendance_id}/photo", photoHandler.GetPhoto)
+		r.Post("/attendance/{attendance_id}/photo", photoHandler.UploadPhoto)
+		r.Group(func(r chi.Router) {
+			// Mutating attendance requests may be retried with an Idempotency-Key
+			r.Use(idempotencyMiddleware.Handle)
+
+			r.Post("/attendance/check-in", attendanceHandler.CheckIn)
+			r.Post("/attendance/check-out", attendanceHandler.CheckOut)
+			r.Post("/attendance/breaks/start", attendanceHandler.StartBreak)
+			r.Post("/attendance/breaks/end", attendanceHandler.EndBreak)
+			r.Post("/attendance/sync", syncHandler.SyncEvents)
+			r.Post("/organizations/{org_id}/attendance", attendanceHandler.CreateManualAttendance)
+			r.Put("/organizations/{org_id}/attendance/{attendance_id}", attendanceHandler.EditAttendance)
+			r.Post("/attendance/{attendance_id}/corrections", correctionHandler.SubmitCorrection)
+		})
+
+		// Corrections
+		r.Get("/organizations/{org_id}/corrections", correctionHandler.ListCorrections)
+		r.Post("/organizations/{org_id}/corrections/{correction_id}/approve", correctionHandler.ApproveCorrection)
+		r.Post("/organizations/{org_id}/corrections/{correction_id}/reject", correctionHandler.RejectCorrection)
 
 		// Reports
 		r.Get("/organizations/{org_id}/reports/groups/{group_id}", reportHandler.GetGroupPerformance)
@@ -11,30 +11,235 @@ type Task struct {
 	LocationName      string    `json:"location_name,omitempty"`
 	Latitude          float64   `json:"latitude,omitempty"`
 	Longitude         float64   `json:"longitude,omitempty"`
-	RadiusMeters      int       `json:"radius_meters,omitempty"`
+	RadiusMeters      int       `json:"radius_meters,omitempty" validate:"gte=0"` // Required for geofencing without a boundary
+	Boundary          *Boundary `json:"boundary,omitempty"`
+	SiteID            *string   `json:"site_id,omitempty" validate:"omitempty,uuid"` // Site the task is done at
 	CreatedAt         time.Time `json:"created_at"`
 }
 
+// Attendance statuses.
+const (
+	AttendanceStatusPresent       = "PRESENT"
+	AttendanceStatusLate          = "LATE"
+	AttendanceStatusAbsent        = "ABSENT"
+	AttendanceStatusNonWorkingDay = "NON_WORKING_DAY" // Checked in on a day the shift does not cover
+)
+
+// Attendance sources.
+const (
+	AttendanceSourceDevice = "DEVICE" // Recorded by the member's own check-in/out
+	AttendanceSourceManual = "MANUAL" // Entered or edited by an owner or manager
+)
+
+// Check-out statuses, set for sessions attributed to a shift instance.
+const (
+	CheckOutStatusOnTime     = "ON_TIME"
+	CheckOutStatusEarlyLeave = "EARLY_LEAVE" // Left before the shift end minus its early-leave tolerance
+)
+
 type Attendance struct {
+	ID                     string     `json:"id"`
+	UserID                 string     `json:"user_id"`
+	OrgID                  string     `json:"org_id"`
+	TaskID                 *string    `json:"task_id,omitempty"`
+	SiteID                 *string    `json:"site_id,omitempty"` // Site the member checked in at
+	CheckInTime            time.Time  `json:"check_in_time"`
+	CheckOutTime           *time.Time `json:"check_out_time,omitempty"`
+	CheckOutStatus         string     `json:"check_out_status,omitempty"` // ON_TIME, EARLY_LEAVE
+	EarlyLeaveMinutes      int        `json:"early_leave_minutes,omitempty"`
+	AutoClosed             bool       `json:"auto_closed"` // Checked out by the system, not the member
+	Offline                bool       `json:"offline"`     // Check-in captured on the device without connectivity and synced later
+	CheckOutOffline        bool       `json:"check_out_offline"`
+	Status                 string     `json:"status"` // PRESENT, LATE, ABSENT, NON_WORKING_DAY
+	LateMinutes            int        `json:"late_minutes,omitempty"`
+	Type                   string     `json:"type"`                    // GENERAL, TASK
+	Source                 string     `json:"source"`                  // DEVICE, MANUAL
+	RecordedBy             *string    `json:"recorded_by,omitempty"`   // User who entered a MANUAL record
+	ProofType              string     `json:"proof_type,omitempty"`    // GPS, QR, KIOSK; set for check-ins made on the device
+	CheckpointID           *string    `json:"checkpoint_id,omitempty"` // Checkpoint whose QR code was scanned
+	KioskID                *string    `json:"kiosk_id,omitempty"`      // Kiosk the member checked in at
+	CheckOutKioskID        *string    `json:"check_out_kiosk_id,omitempty"`
+	PhotoKey               string     `json:"-"` // Storage key of the photo attached to the check-in
+	PhotoContentType       string     `json:"photo_content_type,omitempty"`
+	PhotoSizeBytes         int64      `json:"photo_size_bytes,omitempty"`
+	PhotoUploadedAt        *time.Time `json:"photo_uploaded_at,omitempty"`
+	ShiftApplied           string     `json:"shift_applied,omitempty"`
+	ShiftDate              string     `json:"shift_date,omitempty"`    // Working day of the shift instance, YYYY-MM-DD
+	ShiftSegment           int        `json:"shift_segment,omitempty"` // 1-based segment of a split shift the session is matched to
+	ScheduledStart         *time.Time `json:"scheduled_start,omitempty"`
+	ScheduledEnd           *time.Time `json:"scheduled_end,omitempty"`
+	OvertimeMinutes        int        `json:"overtime_minutes,omitempty"`
+	UnpaidBreakMinutes     int        `json:"unpaid_break_minutes,omitempty"` // Sum of finished unpaid breaks, not stored
+	WorkedMinutes          *int       `json:"worked_minutes,omitempty"`       // Computed for closed sessions, not stored
+	LocationLat            float64    `json:"location_lat"`
+	LocationLong           float64    `json:"location_long"`
+	LocationAccuracy       *float64   `json:"location_accuracy,omitempty"` // Meters, as reported by the device
+	LocationProvider       string     `json:"location_provider,omitempty"`
+	MockLocation           bool       `json:"mock_location"` // The device reported a mock location provider
+	RiskScore              int        `json:"risk_score"`    // 0-100; higher means the location is more likely spoofed
+	RiskReasons            []string   `json:"risk_reasons,omitempty"`
+	ClientIP               string     `json:"client_ip,omitempty"`       // Address the check-in request came from, behind trusted proxies
+	DistanceMeters         *float64   `json:"distance_meters,omitempty"` // Distance from the geofence center, or outside its boundary, at check-in
+	CheckOutLat            *float64   `json:"check_out_lat,omitempty"`
+	CheckOutLong           *float64   `json:"check_out_long,omitempty"`
+	CheckOutDistanceMeters *float64   `json:"check_out_distance_meters,omitempty"` // Distance from the geofence center at check-out
+	Flagged                bool       `json:"flagged"`
+	FlagReason             string     `json:"flag_reason,omitempty"`
+	Note                   string     `json:"note,omitempty"`
+	CheckOutNote           string     `json:"check_out_note,omitempty"`
+	CreatedAt              time.Time  `json:"created_at"`
+}
+
+// Location risk reasons, from the checks run on check-in coordinates.
+const (
+	RiskMockLocation      = "MOCK_LOCATION"      // The device reported a mock location provider
+	RiskPoorAccuracy      = "POOR_ACCURACY"      // Reported accuracy is worse than the allowed radius
+	RiskReusedCoordinates = "REUSED_COORDINATES" // Another member recently checked in at exactly the same coordinates
+	RiskImpossibleTravel  = "IMPOSSIBLE_TRAVEL"  // Too far from the member's previous punch for the time between them
+)
+
+// Check-in violation kinds.
+const (
+	ViolationKindGeofence = "GEOFENCE"
+	ViolationKindNetwork  = "NETWORK"
+)
+
+// CheckInViolation records a check-in refused by the organization's
+// geofence or network allowlist.
+type CheckInViolation struct {
+	ID             string    `json:"id"`
+	OrgID          string    `json:"org_id"`
+	UserID         string    `json:"user_id"`
+	Kind           string    `json:"kind"` // GEOFENCE, NETWORK
+	ClientIP       string    `json:"client_ip,omitempty"`
+	LocationLat    float64   `json:"location_lat"`
+	LocationLong   float64   `json:"location_long"`
+	DistanceMeters *float64  `json:"distance_meters,omitempty"` // Distance from the geofence center
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// PunchLocation is where and when a member last checked in or out.
+type PunchLocation struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+}
+
+// Flag marks the attendance for review, appending reason to any earlier ones.
+func (a *Attendance) Flag(reason string) {
+	a.Flagged = true
+	if a.FlagReason == "" {
+		a.FlagReason = reason
+		return
+	}
+	a.FlagReason += "; " + reason
+}
+
+// DefaultBreakType is the unpaid break used when the shift defines no break types.
+const DefaultBreakType = "BREAK"
+
+// AttendanceBreak is a break interval within an attendance session. Paid is
+// copied from the shift's break type when the break starts.
+type AttendanceBreak struct {
 	ID           string     `json:"id"`
-	UserID       string     `json:"user_id"`
-	OrgID        string     `json:"org_id"`
-	TaskID       *string    `json:"task_id,omitempty"`
-	CheckInTime  time.Time  `json:"check_in_time"`
-	CheckOutTime *time.Time `json:"check_out_time,omitempty"`
-	Status       string     `json:"status"` // PRESENT, LATE, ABSENT
-	Type         string     `json:"type"`   // GENERAL, TASK
-	ShiftApplied string     `json:"shift_applied,omitempty"`
-	LocationLat  float64    `json:"location_lat"`
-	LocationLong float64    `json:"location_long"`
-	Note         string     `json:"note,omitempty"`
+	AttendanceID string     `json:"attendance_id"`
+	Type         string     `json:"type"`
+	Paid         bool       `json:"paid"`
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
 	CreatedAt    time.Time  `json:"created_at"`
 }
 
+type StartBreakRequest struct {
+	OrganizationID string `json:"organization_id" validate:"omitempty,uuid"` // Needed only when checked in to several organizations
+	Type           string `json:"type" validate:"max=50"`                    // One of the shift's break types; defaults to BREAK
+}
+
+type EndBreakRequest struct {
+	OrganizationID string `json:"organization_id" validate:"omitempty,uuid"` // Needed only when checked in to several organizations
+}
+
+// AttendanceFilter selects attendance records for listing. Records are
+// ordered newest first; CursorTime and CursorID continue after a given record.
+type AttendanceFilter struct {
+	UserID       string
+	OrgID        string
+	GroupID      string
+	SiteID       string
+	From         *time.Time // Inclusive
+	To           *time.Time // Exclusive
+	Type         string
+	Status       string
+	Open         *bool // Only open (true) or closed (false) sessions
+	MinRiskScore int   // Only records with at least this location risk score
+	CursorTime   *time.Time
+	CursorID     string
+	Limit        int
+}
+
+type AttendancePage struct {
+	Items      []*Attendance `json:"items"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+type AttendanceDetail struct {
+	Attendance
+	User User `json:"user"`
+}
+
+type TeamAttendancePage struct {
+	Items      []*AttendanceDetail `json:"items"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// ScheduledMember is an organization member on a schedule, either through
+// their group or through a schedule assignment. For an assignment,
+// EffectiveFrom and EffectiveTo bound the dates it covers; a member's group
+// schedule does not apply on dates one of their assignments covers.
+type ScheduledMember struct {
+	OrgID         string
+	UserID        string
+	JoinedAt      time.Time
+	Schedule      Schedule
+	EffectiveFrom string  // Empty for the group schedule
+	EffectiveTo   *string // Last day of a bounded assignment
+}
+
+// ManualAttendanceRequest is a complete attendance record entered by an owner
+// or manager on behalf of a member.
+type ManualAttendanceRequest struct {
+	UserID       string    `json:"user_id" validate:"required,uuid"`
+	TaskID       *string   `json:"task_id" validate:"omitempty,uuid"`
+	CheckInTime  time.Time `json:"check_in_time" validate:"required"`
+	CheckOutTime time.Time `json:"check_out_time" validate:"required"`
+	Note         string    `json:"note" validate:"required,max=500"`
+}
+
+// AttendanceEditRequest replaces the times of an existing attendance record.
+type AttendanceEditRequest struct {
+	CheckInTime  time.Time `json:"check_in_time" validate:"required"`
+	CheckOutTime time.Time `json:"check_out_time" validate:"required"`
+	Note         string    `json:"note" validate:"required,max=500"`
+}
+
 type CheckInRequest struct {
-	OrganizationID string  `json:"organization_id" validate:"required,uuid"`
-	TaskID         *string `json:"task_id" validate:"omitempty,uuid"`
-	Latitude       float64 `json:"latitude" validate:"required"`
-	Longitude      float64 `json:"longitude" validate:"required"`
-	Note           string  `json:"note"`
+	OrganizationID string   `json:"organization_id" validate:"required,uuid"`
+	TaskID         *string  `json:"task_id" validate:"omitempty,uuid"`
+	Latitude       float64  `json:"latitude" validate:"required_without=QRToken"`
+	Longitude      float64  `json:"longitude" validate:"required_without=QRToken"`
+	QRToken        string   `json:"qr_token" validate:"max=200"`         // Token from a checkpoint's QR code; proves presence in place of coordinates
+	Accuracy       *float64 `json:"accuracy" validate:"omitempty,gte=0"` // Horizontal accuracy in meters, as reported by the device
+	Provider       string   `json:"provider" validate:"max=50"`          // Location provider reported by the device, e.g. gps, network, fused
+	MockLocation   bool     `json:"mock_location"`                       // The device reports the location came from a mock provider
+	Note           string   `json:"note"`
+}
+
+// CheckOutRequest is optional; clients that send no body close their only
+// open session without a location.
+type CheckOutRequest struct {
+	OrganizationID string   `json:"organization_id" validate:"omitempty,uuid"` // Needed only when checked in to several organizations
+	AttendanceID   string   `json:"attendance_id" validate:"omitempty,uuid"`   // Close this session instead of the open one in the organization
+	Latitude       *float64 `json:"latitude" validate:"required_with=Longitude"`
+	Longitude      *float64 `json:"longitude" validate:"required_with=Latitude"`
+	Note           string   `json:"note" validate:"max=500"`
 }
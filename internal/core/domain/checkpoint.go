@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Check-in proof types: how the member showed they were on site.
+const (
+	ProofTypeGPS   = "GPS"   // Device coordinates, held to the geofence
+	ProofTypeQR    = "QR"    // Rotating token scanned from a checkpoint's QR code
+	ProofTypeKiosk = "KIOSK" // PIN or badge entered at an organization's kiosk
+)
+
+// DefaultCheckpointRotationSeconds is how often a checkpoint's token changes
+// when no rotation is given.
+const DefaultCheckpointRotationSeconds = 30
+
+// Checkpoint is a screen posted at a physical site that shows a rotating QR
+// code. Each checkpoint signs its tokens with its own secret.
+type Checkpoint struct {
+	ID              string    `json:"id"`
+	OrgID           string    `json:"org_id"`
+	Name            string    `json:"name" validate:"required,max=255"`
+	RotationSeconds int       `json:"rotation_seconds" validate:"omitempty,gte=10,lte=3600"` // Defaults to 30
+	Secret          []byte    `json:"-"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// CheckpointToken is the token a checkpoint screen should currently display.
+type CheckpointToken struct {
+	CheckpointID string    `json:"checkpoint_id"`
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expires_at"` // When the next token replaces this one
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// Correction request statuses.
+const (
+	CorrectionStatusPending  = "PENDING"
+	CorrectionStatusApproved = "APPROVED"
+	CorrectionStatusRejected = "REJECTED"
+)
+
+// AttendanceCorrection is a member's request to change the times of one of
+// their attendance records. The original values are kept for audit and are
+// refreshed when the correction is approved.
+type AttendanceCorrection struct {
+	ID                    string     `json:"id"`
+	AttendanceID          string     `json:"attendance_id"`
+	UserID                string     `json:"user_id"`
+	OrgID                 string     `json:"org_id"`
+	RequestedCheckInTime  *time.Time `json:"requested_check_in_time,omitempty"`
+	RequestedCheckOutTime *time.Time `json:"requested_check_out_time,omitempty"`
+	OriginalCheckInTime   time.Time  `json:"original_check_in_time"`
+	OriginalCheckOutTime  *time.Time `json:"original_check_out_time,omitempty"`
+	OriginalStatus        string     `json:"original_status"`
+	Reason                string     `json:"reason"`
+	Status                string     `json:"status"` // PENDING, APPROVED, REJECTED
+	ReviewedBy            *string    `json:"reviewed_by,omitempty"`
+	ReviewedAt            *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote            string     `json:"review_note,omitempty"`
+	CreatedAt             time.Time  `json:"created_at"`
+}
+
+type CorrectionFilter struct {
+	OrgID  string
+	UserID string
+	Status string
+}
+
+type CorrectionRequest struct {
+	CheckInTime  *time.Time `json:"check_in_time"`
+	CheckOutTime *time.Time `json:"check_out_time"`
+	Reason       string     `json:"reason" validate:"required,max=500"`
+}
+
+type CorrectionReviewRequest struct {
+	Note string `json:"note" validate:"max=500"`
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "fmt"
+
 type ErrorResponse struct {
 	Message string              `json:"message"`
 	Errors  map[string][]string `json:"errors,omitempty"`
@@ -12,3 +14,27 @@ type DuplicateError struct {
 func (e *DuplicateError) Error() string {
 	return e.Field + " already exists"
 }
+
+// GeofenceError is returned when a check-in happens outside the allowed radius,
+// or outside the boundary when RadiusMeters is zero.
+type GeofenceError struct {
+	DistanceMeters float64
+	RadiusMeters   int
+}
+
+func (e *GeofenceError) Error() string {
+	if e.RadiusMeters == 0 {
+		return fmt.Sprintf("location is %.0fm outside the allowed area", e.DistanceMeters)
+	}
+	return fmt.Sprintf("location is %.0fm away, outside the allowed radius of %dm", e.DistanceMeters, e.RadiusMeters)
+}
+
+// NetworkError is returned when a check-in comes from outside the
+// organization's allowed networks.
+type NetworkError struct {
+	ClientIP string
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("client address %s is outside the organization's allowed networks", e.ClientIP)
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// IdempotencyKey records a request made with an Idempotency-Key header and,
+// once it has finished, the response to replay for retries of the same key.
+type IdempotencyKey struct {
+	UserID         string
+	Key            string
+	RequestHash    string // SHA-256 of the method, path and body
+	ResponseStatus *int   // Nil while the request is in progress
+	ResponseBody   []byte
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
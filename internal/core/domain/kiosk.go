@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Kiosk is a shared device, such as a tablet at a factory entrance, that
+// checks members of one organization in and out by PIN or badge. It
+// authenticates with a device token that is shown only at registration.
+type Kiosk struct {
+	ID          string     `json:"id"`
+	OrgID       string     `json:"org_id"`
+	Name        string     `json:"name" validate:"required,max=255"`
+	TokenHash   string     `json:"-"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"` // PIN and badge entry is refused until then
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// KioskRegistration is returned once, when a kiosk is registered.
+type KioskRegistration struct {
+	Kiosk *Kiosk `json:"kiosk"`
+	Token string `json:"token"` // Device token; send it as "Authorization: Kiosk <token>"
+}
+
+// KioskCredentialsRequest replaces a member's kiosk PIN and badge. An empty
+// value removes the credential.
+type KioskCredentialsRequest struct {
+	PIN     string `json:"pin" validate:"omitempty,numeric,min=4,max=8"`
+	BadgeID string `json:"badge_id" validate:"max=100"`
+}
+
+// KioskPunchRequest identifies the member at a kiosk by PIN or badge.
+type KioskPunchRequest struct {
+	PIN     string `json:"pin" validate:"required_without=BadgeID,omitempty,numeric,min=4,max=8"`
+	BadgeID string `json:"badge_id" validate:"max=100"`
+	Note    string `json:"note" validate:"max=500"`
+}
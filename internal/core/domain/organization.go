@@ -2,13 +2,33 @@ package domain
 
 import "time"
 
+// Geofence enforcement modes for general check-ins. The network allowlist
+// uses the same modes.
+const (
+	GeofenceModeOff    = "OFF"    // Location is recorded but not checked
+	GeofenceModeWarn   = "WARN"   // Out-of-radius check-ins are accepted and flagged for review
+	GeofenceModeReject = "REJECT" // Out-of-radius check-ins are rejected
+)
+
+// Location rules: how the geofence and network allowlist combine when an
+// organization enforces both.
+const (
+	LocationRuleAll = "ALL" // Each check applies on its own
+	LocationRuleAny = "ANY" // A check-in passing either check is accepted
+)
+
 type Organization struct {
-	ID                  string    `json:"id"`
-	Name                string    `json:"name" validate:"required"`
-	Email               string    `json:"email" validate:"required,email"`
-	DefaultLocationLat  float64   `json:"default_location_lat" validate:"required"`
-	DefaultLocationLong float64   `json:"default_location_long" validate:"required"`
-	CreatedAt           time.Time `json:"created_at"`
+	ID                   string    `json:"id"`
+	Name                 string    `json:"name" validate:"required"`
+	Email                string    `json:"email" validate:"required,email"`
+	DefaultLocationLat   float64   `json:"default_location_lat" validate:"required"`
+	DefaultLocationLong  float64   `json:"default_location_long" validate:"required"`
+	GeofenceRadiusMeters int       `json:"geofence_radius_meters" validate:"required_if=GeofenceMode WARN,required_if=GeofenceMode REJECT,gte=0"`
+	GeofenceMode         string    `json:"geofence_mode" validate:"omitempty,oneof=OFF WARN REJECT"`                                                            // OFF, WARN, REJECT
+	NetworkMode          string    `json:"network_mode" validate:"omitempty,oneof=OFF WARN REJECT"`                                                             // OFF, WARN, REJECT; applies to check-ins from outside AllowedNetworks
+	AllowedNetworks      []string  `json:"allowed_networks,omitempty" validate:"required_if=NetworkMode WARN,required_if=NetworkMode REJECT,max=100,dive,cidr"` // CIDR ranges, e.g. 203.0.113.0/24
+	LocationRule         string    `json:"location_rule" validate:"omitempty,oneof=ALL ANY"`                                                                    // ALL, ANY
+	CreatedAt            time.Time `json:"created_at"`
 }
 
 type OrganizationMember struct {
@@ -26,24 +46,99 @@ type OrganizationMemberDetail struct {
 }
 
 type Shift struct {
-	ID                 string    `json:"id"`
-	OrgID              string    `json:"org_id"`
-	Name               string    `json:"name" validate:"required"`
-	StartTime          string    `json:"start_time" validate:"required"` // HH:MM
-	EndTime            string    `json:"end_time" validate:"required"`   // HH:MM
-	Timezone           string    `json:"timezone" validate:"required"`
-	AllowedLateMinutes int       `json:"allowed_late_minutes"`
-	WorkingDays        []string  `json:"working_days" validate:"required,min=1"`
-	CreatedAt          time.Time `json:"created_at"`
+	ID                       string         `json:"id"`
+	OrgID                    string         `json:"org_id"`
+	Name                     string         `json:"name" validate:"required"`
+	StartTime                string         `json:"start_time" validate:"required_without=Segments,omitempty,datetime=15:04"` // HH:MM; set from the segments of a split shift
+	EndTime                  string         `json:"end_time" validate:"required_without=Segments,omitempty,datetime=15:04"`   // HH:MM
+	Segments                 []ShiftSegment `json:"segments,omitempty" validate:"omitempty,min=2,max=4,dive"`                 // Stretches of a split shift, in order; late and early-leave tolerances apply to each
+	Timezone                 string         `json:"timezone" validate:"required_without=SiteID,omitempty,timezone"`           // IANA zone, e.g. Asia/Tbilisi; defaults to the site's
+	AllowedLateMinutes       int            `json:"allowed_late_minutes" validate:"gte=0"`
+	AllowedEarlyLeaveMinutes int            `json:"allowed_early_leave_minutes" validate:"gte=0"`
+	WorkingDays              []string       `json:"working_days" validate:"required,min=1,dive,oneof=MON TUE WED THU FRI SAT SUN"`
+	BreakTypes               []BreakType    `json:"break_types,omitempty" validate:"unique=Name,dive"` // Breaks members may take; none means a single unpaid BREAK type
+	SiteID                   *string        `json:"site_id,omitempty" validate:"omitempty,uuid"`       // Where the shift is worked
+	CreatedAt                time.Time      `json:"created_at"`
+}
+
+// ShiftSegment is one stretch of a split shift, such as 07:00-11:00 of a
+// shift that resumes at 16:00. Segments follow one another, so one starting
+// at an earlier clock time than the previous one ends runs past midnight.
+type ShiftSegment struct {
+	StartTime string `json:"start_time" validate:"required,datetime=15:04"` // HH:MM
+	EndTime   string `json:"end_time" validate:"required,datetime=15:04"`   // HH:MM
+}
+
+// BreakType is a kind of break members of a shift may take. Unpaid breaks are
+// subtracted from worked time.
+type BreakType struct {
+	Name string `json:"name" validate:"required,max=50"`
+	Paid bool   `json:"paid"`
+}
+
+// ShiftOccurrence is a concrete instance of a shift, or of one segment of a
+// split shift. Date is the local calendar date (YYYY-MM-DD) the shift starts
+// on; overnight instances end on the following day.
+type ShiftOccurrence struct {
+	Date    string    `json:"date"`
+	Segment int       `json:"segment,omitempty"` // 1-based segment of a split shift
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Shift   *Shift    `json:"-"` // The shift worked, which varies by date in a rotation
+}
+
+// Rotation is a repeating cycle of shifts and days off, such as 4-on/4-off or
+// alternating day and night weeks. The first day of the cycle falls on
+// AnchorDate and each date after it takes the next day, wrapping around; the
+// rotation's shifts are worked on the days it gives them regardless of their
+// WorkingDays.
+type Rotation struct {
+	ID         string            `json:"id"`
+	OrgID      string            `json:"org_id"`
+	Name       string            `json:"name" validate:"required,max=255"`
+	AnchorDate string            `json:"anchor_date" validate:"required,datetime=2006-01-02"`        // YYYY-MM-DD in the rotation's timezone
+	Days       []*string         `json:"days" validate:"required,min=1,max=366,dive,omitempty,uuid"` // Shift ID for each day of the cycle; null is a day off
+	Timezone   string            `json:"timezone"`                                                   // Shared by the rotation's shifts
+	Shifts     map[string]*Shift `json:"-"`                                                          // The rotation's shifts by ID, loaded for resolving
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// Schedule is what a member or group works to: a single shift on its working
+// days, or a rotation.
+type Schedule struct {
+	Shift    *Shift
+	Rotation *Rotation
 }
 
 type Group struct {
-	ID        string    `json:"id"`
-	OrgID     string    `json:"org_id"`
-	Name      string    `json:"name" validate:"required"`
-	ShiftID   *string   `json:"shift_id,omitempty" validate:"omitempty,uuid"`
-	ManagerID *string   `json:"manager_id,omitempty" validate:"omitempty,uuid"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         string    `json:"id"`
+	OrgID      string    `json:"org_id"`
+	Name       string    `json:"name" validate:"required"`
+	ShiftID    *string   `json:"shift_id,omitempty" validate:"omitempty,uuid"`
+	RotationID *string   `json:"rotation_id,omitempty" validate:"omitempty,uuid"` // In place of a shift
+	ManagerID  *string   `json:"manager_id,omitempty" validate:"omitempty,uuid"`
+	SiteID     *string   `json:"site_id,omitempty" validate:"omitempty,uuid"` // Where the group works; takes precedence over the shift's site
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ScheduleAssignment puts a member on a shift or a rotation from EffectiveFrom
+// through EffectiveTo, overriding their group's schedule on those dates. Dates
+// are YYYY-MM-DD in the shift's or rotation's timezone; without EffectiveTo the
+// assignment runs until it is ended or replaced.
+type ScheduleAssignment struct {
+	ID            string    `json:"id"`
+	OrgID         string    `json:"org_id"`
+	UserID        string    `json:"user_id"`
+	ShiftID       *string   `json:"shift_id,omitempty" validate:"omitempty,uuid"`
+	RotationID    *string   `json:"rotation_id,omitempty" validate:"omitempty,uuid"` // In place of a shift
+	EffectiveFrom string    `json:"effective_from" validate:"required,datetime=2006-01-02"`
+	EffectiveTo   *string   `json:"effective_to,omitempty" validate:"omitempty,datetime=2006-01-02"` // Last day, inclusive
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// EndScheduleAssignmentRequest sets the last day of a schedule assignment.
+type EndScheduleAssignmentRequest struct {
+	EffectiveTo string `json:"effective_to" validate:"required,datetime=2006-01-02"`
 }
 
 type InviteEmployeeRequest struct {
@@ -3,6 +3,11 @@ package domain
 type GroupPerformanceReport struct {
 	GroupName         string `json:"group_name"`
 	AssignedShift     string `json:"assigned_shift"`
+	From              string `json:"from"` // YYYY-MM-DD
+	To                string `json:"to"`   // YYYY-MM-DD
 	TotalLateCheckins int    `json:"total_late_checkins"`
+	ExpectedShifts    int    `json:"expected_shifts"` // Shift instances in the period times group members
+	AttendedShifts    int    `json:"attended_shifts"`
+	ManualEntries     int    `json:"manual_entries"` // Records in the period entered by an owner or manager
 	AttendanceRate    string `json:"attendance_rate"`
 }
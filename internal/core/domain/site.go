@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Site is one of an organization's work locations, such as a branch or a
+// warehouse. Its radius, or its boundary when it has one, is the geofence for
+// check-ins made there.
+type Site struct {
+	ID           string    `json:"id"`
+	OrgID        string    `json:"org_id"`
+	Name         string    `json:"name" validate:"required,max=255"`
+	Address      string    `json:"address,omitempty" validate:"max=500"`
+	Latitude     float64   `json:"latitude" validate:"required,latitude"`
+	Longitude    float64   `json:"longitude" validate:"required,longitude"`
+	RadiusMeters int       `json:"radius_meters" validate:"required_without=Boundary,gte=0"`
+	Boundary     *Boundary `json:"boundary,omitempty"`                    // Replaces the radius for irregular sites
+	Timezone     string    `json:"timezone" validate:"required,timezone"` // IANA zone; the default for shifts at the site
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Boundary is a GeoJSON Polygon or MultiPolygon geometry outlining a geofence.
+// Positions are [longitude, latitude] and every ring must be closed.
+type Boundary struct {
+	Type        string          `json:"type" validate:"required,oneof=Polygon MultiPolygon"`
+	Coordinates json.RawMessage `json:"coordinates" validate:"required" swaggertype:"array,object"`
+}
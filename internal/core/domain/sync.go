@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// Offline sync event types.
+const (
+	SyncEventCheckIn  = "CHECK_IN"
+	SyncEventCheckOut = "CHECK_OUT"
+)
+
+// Offline sync results.
+const (
+	SyncStatusAccepted = "ACCEPTED"
+	SyncStatusRejected = "REJECTED"
+)
+
+// SyncEvent is a check-in or check-out the device queued while offline.
+type SyncEvent struct {
+	ClientEventID      string    `json:"client_event_id" validate:"required,max=100"` // Unique per user; retried events are not applied twice
+	Type               string    `json:"type" validate:"required,oneof=CHECK_IN CHECK_OUT"`
+	OrganizationID     string    `json:"organization_id" validate:"required_if=Type CHECK_IN,omitempty,uuid"`
+	TaskID             *string   `json:"task_id" validate:"omitempty,uuid"`
+	CapturedAt         time.Time `json:"captured_at" validate:"required"` // Device clock
+	ClockOffsetSeconds int       `json:"clock_offset_seconds"`            // Device clock minus server clock, as last measured by the device
+	Latitude           *float64  `json:"latitude" validate:"required_if=Type CHECK_IN,required_with=Longitude"`
+	Longitude          *float64  `json:"longitude" validate:"required_if=Type CHECK_IN,required_with=Latitude"`
+	Accuracy           *float64  `json:"accuracy" validate:"omitempty,gte=0"` // Check-in location signals, as on CheckInRequest
+	Provider           string    `json:"provider" validate:"max=50"`
+	MockLocation       bool      `json:"mock_location"`
+	Note               string    `json:"note" validate:"max=500"`
+}
+
+// SyncRequest carries queued events, oldest first.
+type SyncRequest struct {
+	Events []SyncEvent `json:"events" validate:"required,min=1,max=100,dive"`
+}
+
+// OfflineEvent is the outcome of one synced event. Accepted events are stored
+// so that a retried sync returns the original result.
+type OfflineEvent struct {
+	ID                 string    `json:"-"`
+	UserID             string    `json:"-"`
+	ClientEventID      string    `json:"client_event_id"`
+	Type               string    `json:"type"`
+	OrgID              string    `json:"org_id,omitempty"`
+	AttendanceID       *string   `json:"attendance_id,omitempty"`
+	CapturedAt         time.Time `json:"captured_at"`
+	ClockOffsetSeconds int       `json:"clock_offset_seconds"`
+	EventTime          time.Time `json:"event_time"` // CapturedAt corrected by the clock offset
+	Status             string    `json:"status"`     // ACCEPTED, REJECTED
+	Error              string    `json:"error,omitempty"`
+	Flagged            bool      `json:"flagged"` // The resulting attendance record is flagged for review
+	FlagReason         string    `json:"flag_reason,omitempty"`
+	Duplicate          bool      `json:"duplicate,omitempty"` // Synced before; this is the original result
+	ReceivedAt         time.Time `json:"received_at"`
+}
+
+type SyncResponse struct {
+	Results []*OfflineEvent `json:"results"`
+}
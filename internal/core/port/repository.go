@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/syst3mctl/check-in-api/internal/core/domain"
 )
@@ -25,19 +26,143 @@ type OrgRepository interface {
 	RemoveOrganizationMember(ctx context.Context, orgID, userID string) error
 	AddMember(ctx context.Context, member *domain.OrganizationMember) error
 	CreateShift(ctx context.Context, shift *domain.Shift) error
+	// GetShiftByID returns nil if the shift does not exist.
+	GetShiftByID(ctx context.Context, id string) (*domain.Shift, error)
 	CreateGroup(ctx context.Context, group *domain.Group) error
 	UpdateMemberGroup(ctx context.Context, orgID, userID, groupID string) error
+	// CreateSite returns a DuplicateError if the organization already has a
+	// site with the same name; so does UpdateSite.
+	CreateSite(ctx context.Context, site *domain.Site) error
+	// GetSiteByID returns nil if the site does not exist.
+	GetSiteByID(ctx context.Context, id string) (*domain.Site, error)
+	ListSites(ctx context.Context, orgID string) ([]*domain.Site, error)
+	// UpdateSite and DeleteSite report whether the organization has the site.
+	UpdateSite(ctx context.Context, site *domain.Site) (bool, error)
+	DeleteSite(ctx context.Context, orgID, id string) (bool, error)
+	CreateScheduleAssignment(ctx context.Context, assignment *domain.ScheduleAssignment) error
+	// GetScheduleAssignmentByID returns nil if the assignment does not exist.
+	GetScheduleAssignmentByID(ctx context.Context, id string) (*domain.ScheduleAssignment, error)
+	// ListScheduleAssignments returns the member's assignments, past ones
+	// included, by effective date.
+	ListScheduleAssignments(ctx context.Context, orgID, userID string) ([]*domain.ScheduleAssignment, error)
+	SetScheduleAssignmentEnd(ctx context.Context, id string, effectiveTo *string) error
+	DeleteScheduleAssignment(ctx context.Context, id string) error
+	// CreateRotation returns a DuplicateError if the organization already
+	// has a rotation with the same name.
+	CreateRotation(ctx context.Context, rotation *domain.Rotation) error
+	// GetRotationByID returns nil if the rotation does not exist. Its Shifts
+	// are not loaded.
+	GetRotationByID(ctx context.Context, id string) (*domain.Rotation, error)
+	ListRotations(ctx context.Context, orgID string) ([]*domain.Rotation, error)
 }
 
 type AttendanceRepository interface {
 	CreateTask(ctx context.Context, task *domain.Task) error
 	GetTaskByID(ctx context.Context, id string) (*domain.Task, error)
+	CreateCheckpoint(ctx context.Context, checkpoint *domain.Checkpoint) error
+	GetCheckpointByID(ctx context.Context, id string) (*domain.Checkpoint, error)
+	ListCheckpoints(ctx context.Context, orgID string) ([]*domain.Checkpoint, error)
+	// UseCheckpointToken records that the user checked in with the
+	// checkpoint's token for the window. A second use returns a DuplicateError.
+	UseCheckpointToken(ctx context.Context, checkpointID, userID string, window int64) error
 	CreateAttendance(ctx context.Context, attendance *domain.Attendance) error
 	UpdateAttendance(ctx context.Context, attendance *domain.Attendance) error
-	GetLatestAttendance(ctx context.Context, userID string) (*domain.Attendance, error)
-	GetMemberGroup(ctx context.Context, orgID, userID string) (*domain.Group, *domain.Shift, error)
+	GetAttendanceByID(ctx context.Context, id string) (*domain.Attendance, error)
+	CreateCheckInViolation(ctx context.Context, violation *domain.CheckInViolation) error
+	// ListCheckInViolations returns the organization's latest violations,
+	// newest first.
+	ListCheckInViolations(ctx context.Context, orgID string, limit int) ([]*domain.CheckInViolation, error)
+	// SetAttendancePhoto stores the photo metadata from attendance. It
+	// returns false if the record already has a photo.
+	SetAttendancePhoto(ctx context.Context, attendance *domain.Attendance) (bool, error)
+	// ListOpenAttendance returns the user's open sessions, newest first, in
+	// the organization or, when orgID is empty, in any organization.
+	ListOpenAttendance(ctx context.Context, userID, orgID string) ([]*domain.Attendance, error)
+	ListAttendance(ctx context.Context, filter domain.AttendanceFilter) ([]*domain.Attendance, error)
+	// GetLastPunchLocation returns the user's latest check-in or check-out
+	// with a location before the given time, or nil if there is none.
+	GetLastPunchLocation(ctx context.Context, userID string, before time.Time) (*domain.PunchLocation, error)
+	// CoordinatesUsedByOthers reports whether another user has checked in at
+	// exactly these coordinates since the given time.
+	CoordinatesUsedByOthers(ctx context.Context, userID string, lat, long float64, since time.Time) (bool, error)
+	ListOrgAttendance(ctx context.Context, filter domain.AttendanceFilter) ([]*domain.AttendanceDetail, error)
+	// GetMemberGroup returns the member's group, or nil if they have none,
+	// and the schedule in effect at the given time, or nil if they have none:
+	// that of their schedule assignment covering its date in the assigned
+	// shift's or rotation's timezone, otherwise the group's. A rotation comes
+	// with its Shifts loaded.
+	GetMemberGroup(ctx context.Context, orgID, userID string, at time.Time) (*domain.Group, *domain.Schedule, error)
+	// ListScheduledMembers returns the members' group schedules and their
+	// schedule assignments that had not ended before the date of since, with
+	// the Shifts of rotations loaded.
+	ListScheduledMembers(ctx context.Context, since time.Time) ([]*domain.ScheduledMember, error)
+	// CreateAbsence inserts an ABSENT record unless the member already has
+	// attendance for the shift instance. It reports whether a row was inserted.
+	CreateAbsence(ctx context.Context, attendance *domain.Attendance) (bool, error)
+	// ListStaleSessions returns open sessions whose scheduled end is before
+	// shiftEndedBefore, or that have no scheduled end and began before checkedInBefore.
+	ListStaleSessions(ctx context.Context, shiftEndedBefore, checkedInBefore time.Time) ([]*domain.Attendance, error)
+	// CloseAttendance checks out a session only if it is still open. It
+	// reports whether the session was closed.
+	CloseAttendance(ctx context.Context, attendance *domain.Attendance) (bool, error)
+	GetOpenBreak(ctx context.Context, attendanceID string) (*domain.AttendanceBreak, error)
+	CreateBreak(ctx context.Context, brk *domain.AttendanceBreak) error
+	// EndBreak ends the session's running break, if any, and returns it.
+	EndBreak(ctx context.Context, attendanceID string, at time.Time) (*domain.AttendanceBreak, error)
+}
+
+type CorrectionRepository interface {
+	CreateCorrection(ctx context.Context, correction *domain.AttendanceCorrection) error
+	GetCorrectionByID(ctx context.Context, id string) (*domain.AttendanceCorrection, error)
+	ListCorrections(ctx context.Context, filter domain.CorrectionFilter) ([]*domain.AttendanceCorrection, error)
+	// ReviewCorrection records the review only if the correction is still
+	// pending. It reports whether the correction was updated.
+	ReviewCorrection(ctx context.Context, correction *domain.AttendanceCorrection) (bool, error)
 }
 
 type ReportRepository interface {
-	GetGroupPerformance(ctx context.Context, groupID string) (*domain.GroupPerformanceReport, error)
+	GetGroupPerformance(ctx context.Context, groupID, from, to string) (*domain.GroupPerformanceReport, error)
+	// GetGroupSchedule returns the group's shift or rotation, with the
+	// rotation's Shifts loaded, or nil if it has neither.
+	GetGroupSchedule(ctx context.Context, groupID string) (*domain.Schedule, error)
+	CountGroupMembers(ctx context.Context, groupID string) (int, error)
+}
+
+type IdempotencyRepository interface {
+	// CreateIdempotencyKey reserves the key for the user. A key that has
+	// expired, or whose request was abandoned before staleBefore, is taken
+	// over; otherwise a DuplicateError is returned.
+	CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, staleBefore time.Time) error
+	GetIdempotencyKey(ctx context.Context, userID, key string) (*domain.IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, userID, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+type SyncRepository interface {
+	// CreateOfflineEvent returns a DuplicateError if the user has already
+	// synced an event with the same client event ID.
+	CreateOfflineEvent(ctx context.Context, event *domain.OfflineEvent) error
+	GetOfflineEvent(ctx context.Context, userID, clientEventID string) (*domain.OfflineEvent, error)
+}
+
+type KioskRepository interface {
+	CreateKiosk(ctx context.Context, kiosk *domain.Kiosk) error
+	GetKioskByID(ctx context.Context, id string) (*domain.Kiosk, error)
+	GetKioskByTokenHash(ctx context.Context, tokenHash string) (*domain.Kiosk, error)
+	ListKiosks(ctx context.Context, orgID string) ([]*domain.Kiosk, error)
+	RevokeKiosk(ctx context.Context, id string, at time.Time) error
+	// RecordFailedAttempt stores an unrecognized PIN or badge and returns how
+	// many the kiosk has had from the same source since the given time,
+	// leaving out those made before the kiosk's last lockout ended.
+	RecordFailedAttempt(ctx context.Context, id, source string, at, since time.Time) (int, error)
+	LockKiosk(ctx context.Context, id string, lockedUntil time.Time) error
+	// SetMemberCredentials stores the member's PIN hash and badge, nil
+	// clearing either. It returns false if the user is not a member and a
+	// DuplicateError if another member already has the PIN or badge.
+	SetMemberCredentials(ctx context.Context, orgID, userID string, pinHash, badgeID *string) (bool, error)
+	// FindMemberByPIN and FindMemberByBadge return the member's user ID, or
+	// "" if no member has the credential.
+	FindMemberByPIN(ctx context.Context, orgID, pinHash string) (string, error)
+	FindMemberByBadge(ctx context.Context, orgID, badgeID string) (string, error)
 }
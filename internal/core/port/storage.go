@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrFileNotFound is returned by FileStorage.Open for a key with no file.
+var ErrFileNotFound = errors.New("file not found")
+
+// FileStorage keeps uploaded files, such as check-in photos, under
+// slash-separated keys chosen by the caller.
+type FileStorage interface {
+	// Save stores the content read from r under key, replacing any file
+	// already there. Nothing is stored if reading r fails.
+	Save(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -2,39 +2,156 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/syst3mctl/check-in-api/internal/core/domain"
 	"github.com/syst3mctl/check-in-api/internal/core/port"
+	"github.com/syst3mctl/check-in-api/internal/pkg/geo"
+	"github.com/syst3mctl/check-in-api/internal/pkg/qrtoken"
+	"github.com/syst3mctl/check-in-api/internal/pkg/validator"
 )
 
 type AttendanceService struct {
-	repo port.AttendanceRepository
+	repo    port.AttendanceRepository
+	orgRepo port.OrgRepository
+	txMgr   port.TransactionManager
+	risk    RiskPolicy
 }
 
-func NewAttendanceService(repo port.AttendanceRepository) *AttendanceService {
-	return &AttendanceService{repo: repo}
+// NewAttendanceService creates the attendance service. GPS check-ins are
+// scored against the risk policy.
+func NewAttendanceService(repo port.AttendanceRepository, orgRepo port.OrgRepository, txMgr port.TransactionManager, risk RiskPolicy) *AttendanceService {
+	return &AttendanceService{repo: repo, orgRepo: orgRepo, txMgr: txMgr, risk: risk}
 }
 
 func (s *AttendanceService) CreateTask(ctx context.Context, task *domain.Task) (*domain.Task, error) {
+	if task.SiteID != nil {
+		if _, err := orgSite(ctx, s.orgRepo, task.OrgID, *task.SiteID); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.repo.CreateTask(ctx, task); err != nil {
 		return nil, err
 	}
 	return task, nil
 }
 
-func (s *AttendanceService) CheckIn(ctx context.Context, userID, orgID string, req *domain.Attendance) (*domain.Attendance, error) {
-	// Check if already checked in
-	latest, err := s.repo.GetLatestAttendance(ctx, userID)
-	if err == nil && latest != nil && latest.CheckOutTime == nil {
+// CreateCheckpoint adds a QR checkpoint with a fresh signing secret (Owner/Manager only).
+func (s *AttendanceService) CreateCheckpoint(ctx context.Context, actorUserID, orgID string, checkpoint *domain.Checkpoint) (*domain.Checkpoint, error) {
+	if err := requireManager(ctx, s.orgRepo, orgID, actorUserID); err != nil {
+		return nil, err
+	}
+	checkpoint.OrgID = orgID
+	if checkpoint.RotationSeconds == 0 {
+		checkpoint.RotationSeconds = domain.DefaultCheckpointRotationSeconds
+	}
+	checkpoint.Secret = make([]byte, 32)
+	if _, err := rand.Read(checkpoint.Secret); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateCheckpoint(ctx, checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// ListCheckpoints returns the organization's checkpoints (Owner/Manager only).
+func (s *AttendanceService) ListCheckpoints(ctx context.Context, actorUserID, orgID string) ([]*domain.Checkpoint, error) {
+	if err := requireManager(ctx, s.orgRepo, orgID, actorUserID); err != nil {
+		return nil, err
+	}
+	checkpoints, err := s.repo.ListCheckpoints(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoints == nil {
+		checkpoints = []*domain.Checkpoint{}
+	}
+	return checkpoints, nil
+}
+
+// CheckpointToken returns the token the checkpoint's screen should show now
+// (Owner/Manager only).
+func (s *AttendanceService) CheckpointToken(ctx context.Context, actorUserID, orgID, checkpointID string) (*domain.CheckpointToken, error) {
+	if err := requireManager(ctx, s.orgRepo, orgID, actorUserID); err != nil {
+		return nil, err
+	}
+	checkpoint, err := s.repo.GetCheckpointByID(ctx, checkpointID)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint == nil || checkpoint.OrgID != orgID {
+		return nil, errors.New("checkpoint not found")
+	}
+
+	rotation := time.Duration(checkpoint.RotationSeconds) * time.Second
+	window := qrtoken.Window(time.Now(), rotation)
+	return &domain.CheckpointToken{
+		CheckpointID: checkpoint.ID,
+		Token:        qrtoken.Sign(checkpoint.Secret, checkpoint.ID, window),
+		ExpiresAt:    qrtoken.WindowEnd(window, rotation),
+	}, nil
+}
+
+// CheckIn opens a session in the organization. The check-in time defaults to
+// now; offline sync sets it to when the device captured the check-in. A QR
+// token from one of the organization's checkpoints, or a kiosk check-in
+// (ProofType KIOSK), proves presence in place of the organization geofence;
+// task geofences still apply. The session is recorded at the task's site, the
+// member's assigned site, or the site a GPS check-in was made at. Check-ins
+// refused by the organization's location policy are recorded as violations.
+func (s *AttendanceService) CheckIn(ctx context.Context, userID, orgID string, req *domain.Attendance, qrToken string) (*domain.Attendance, error) {
+	att, err := s.checkIn(ctx, userID, orgID, req, qrToken)
+	if err != nil {
+		if recordErr := s.recordViolations(ctx, err); recordErr != nil {
+			return nil, recordErr
+		}
+		return nil, err
+	}
+	return att, nil
+}
+
+// checkIn performs CheckIn without recording violations, for callers that
+// run it in a transaction a refusal rolls back.
+func (s *AttendanceService) checkIn(ctx context.Context, userID, orgID string, req *domain.Attendance, qrToken string) (*domain.Attendance, error) {
+	// Check if already checked in. The open-session index catches concurrent
+	// check-ins that both pass this check.
+	open, err := s.repo.ListOpenAttendance(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(open) > 0 {
 		return nil, errors.New("already checked in")
 	}
 
 	req.UserID = userID
 	req.OrgID = orgID
-	req.CheckInTime = time.Now()
-	req.Status = "PRESENT" // Default
+	if req.CheckInTime.IsZero() {
+		req.CheckInTime = time.Now()
+	}
+	req.Status = domain.AttendanceStatusPresent // Default
+	req.Source = domain.AttendanceSourceDevice
+	if req.ProofType == "" {
+		req.ProofType = domain.ProofTypeGPS
+	}
+
+	var tokenWindow int64
+	if qrToken != "" {
+		checkpoint, window, err := s.verifyCheckpointToken(ctx, orgID, qrToken, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		req.ProofType = domain.ProofTypeQR
+		req.CheckpointID = &checkpoint.ID
+		tokenWindow = window
+	}
 
 	if req.TaskID != nil {
 		// Task-based attendance
@@ -42,44 +159,1018 @@ func (s *AttendanceService) CheckIn(ctx context.Context, userID, orgID string, r
 		if err != nil {
 			return nil, err
 		}
-		if task == nil {
+		if task == nil || task.OrgID != orgID {
 			return nil, errors.New("task not found")
 		}
-		// TODO: Validate location (Geofencing)
+		if err := s.checkInGeofence(ctx, orgID, task, req); err != nil {
+			return nil, err
+		}
+		req.SiteID = task.SiteID
 		req.Type = "TASK"
 	} else {
 		// General attendance
 		req.Type = "GENERAL"
-		group, shift, err := s.repo.GetMemberGroup(ctx, orgID, userID)
+		group, schedule, err := s.repo.GetMemberGroup(ctx, orgID, userID, req.CheckInTime)
 		if err != nil {
 			return nil, err
 		}
-		if group == nil {
+		if group == nil && schedule == nil {
 			return nil, errors.New("user not in any group")
 		}
-		if shift != nil {
-			req.ShiftApplied = shift.Name
-			// Validate Shift Time (Simplified logic)
-			// In a real app, parse shift.StartTime and compare with current time in shift.Timezone
+		shift, err := shiftAt(schedule, req.CheckInTime)
+		if err != nil {
+			return nil, err
+		}
+		site, err := s.assignedSite(ctx, orgID, group, shift)
+		if err != nil {
+			return nil, err
+		}
+		if site != nil {
+			req.SiteID = &site.ID
+		}
+		if req.ProofType == domain.ProofTypeGPS {
+			if err := s.checkInOrgPolicy(ctx, orgID, site, req); err != nil {
+				return nil, err
+			}
+		}
+		if schedule != nil {
+			occ, status, lateMinutes, err := evaluateCheckIn(schedule, req.CheckInTime)
+			if err != nil {
+				return nil, err
+			}
+			req.ShiftApplied = shiftApplied(schedule, occ)
+			req.Status = status
+			req.LateMinutes = lateMinutes
+			if occ != nil {
+				req.ShiftDate = occ.Date
+				req.ShiftSegment = occ.Segment
+				req.ScheduledStart = &occ.Start
+				req.ScheduledEnd = &occ.End
+			}
 		}
 	}
 
-	if err := s.repo.CreateAttendance(ctx, req); err != nil {
+	if req.ProofType == domain.ProofTypeGPS {
+		if err := s.assessRisk(ctx, userID, req); err != nil {
+			return nil, err
+		}
+	}
+
+	// The QR token is used in the same transaction as the insert, so a
+	// check-in that fails to save does not burn it.
+	err = s.txMgr.RunInTx(ctx, func(ctx context.Context) error {
+		if req.CheckpointID != nil {
+			if err := s.repo.UseCheckpointToken(ctx, *req.CheckpointID, userID, tokenWindow); err != nil {
+				var dupErr *domain.DuplicateError
+				if errors.As(err, &dupErr) {
+					return errors.New("qr token already used")
+				}
+				return err
+			}
+		}
+		return s.repo.CreateAttendance(ctx, req)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return req, nil
 }
 
-func (s *AttendanceService) CheckOut(ctx context.Context, userID string) error {
-	latest, err := s.repo.GetLatestAttendance(ctx, userID)
+// CheckOut closes the user's open session. The check-out location, when
+// given, is held to the same geofence as the check-in; sessions attributed to
+// a shift instance get an overtime and early-leave evaluation.
+func (s *AttendanceService) CheckOut(ctx context.Context, userID string, req *domain.CheckOutRequest) (*domain.Attendance, error) {
+	return s.checkOutAt(ctx, userID, req, checkOutOptions{at: time.Now()})
+}
+
+// checkOutOptions describes how a check-out was captured.
+type checkOutOptions struct {
+	at      time.Time
+	offline bool     // Captured on the device without connectivity
+	kioskID *string  // Made at a kiosk, which stands in for the location
+	flags   []string // Review reasons the caller already found, such as an implausible device clock
+}
+
+// checkOutAt closes the session at opts.at.
+func (s *AttendanceService) checkOutAt(ctx context.Context, userID string, req *domain.CheckOutRequest, opts checkOutOptions) (*domain.Attendance, error) {
+	latest, err := s.openSession(ctx, userID, req.OrganizationID, req.AttendanceID)
+	if err != nil {
+		return nil, err
+	}
+	at := opts.at
+	if !at.After(latest.CheckInTime) {
+		return nil, errors.New("check-out time must be after check-in time")
+	}
+	for _, reason := range opts.flags {
+		latest.Flag(reason)
+	}
+	latest.CheckOutOffline = opts.offline
+	latest.CheckOutKioskID = opts.kioskID
+
+	if opts.kioskID == nil {
+		if err := s.checkOutGeofence(ctx, latest, req); err != nil {
+			return nil, err
+		}
+	}
+	latest.CheckOutLat = req.Latitude
+	latest.CheckOutLong = req.Longitude
+	latest.CheckOutNote = req.Note
+
+	allowedEarlyLeave := 0
+	if latest.ScheduledEnd != nil {
+		_, schedule, err := s.repo.GetMemberGroup(ctx, latest.OrgID, userID, latest.CheckInTime)
+		if err != nil {
+			return nil, err
+		}
+		shift, err := shiftAt(schedule, latest.CheckInTime)
+		if err != nil {
+			return nil, err
+		}
+		if shift != nil {
+			allowedEarlyLeave = shift.AllowedEarlyLeaveMinutes
+		}
+	}
+
+	// An open break ends with the session, or not at all
+	evaluateCheckOut(latest, at, allowedEarlyLeave)
+	err = s.txMgr.RunInTx(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.EndBreak(ctx, latest.ID, at); err != nil {
+			return err
+		}
+		return s.repo.UpdateAttendance(ctx, latest)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return latest, nil
+}
+
+// StartBreak starts a break in the user's open session. The break type must
+// be one the member's shift defines; shifts without break types allow only
+// the unpaid default.
+func (s *AttendanceService) StartBreak(ctx context.Context, userID, orgID, breakType string) (*domain.AttendanceBreak, error) {
+	latest, err := s.openSession(ctx, userID, orgID, "")
+	if err != nil {
+		return nil, err
+	}
+	open, err := s.repo.GetOpenBreak(ctx, latest.ID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, errors.New("already on break")
+	}
+
+	_, schedule, err := s.repo.GetMemberGroup(ctx, latest.OrgID, userID, latest.CheckInTime)
+	if err != nil {
+		return nil, err
+	}
+	shift, err := shiftAt(schedule, latest.CheckInTime)
+	if err != nil {
+		return nil, err
+	}
+	var types []domain.BreakType
+	if shift != nil {
+		types = shift.BreakTypes
+	}
+	if len(types) == 0 {
+		types = []domain.BreakType{{Name: domain.DefaultBreakType}}
+	}
+	if breakType == "" && len(types) == 1 {
+		breakType = types[0].Name
+	}
+	idx := slices.IndexFunc(types, func(t domain.BreakType) bool { return t.Name == breakType })
+	if idx < 0 {
+		return nil, errors.New("unknown break type")
+	}
+
+	brk := &domain.AttendanceBreak{
+		AttendanceID: latest.ID,
+		Type:         types[idx].Name,
+		Paid:         types[idx].Paid,
+		StartTime:    time.Now(),
+	}
+	if err := s.repo.CreateBreak(ctx, brk); err != nil {
+		return nil, err
+	}
+	return brk, nil
+}
+
+// EndBreak ends the running break in the user's open session.
+func (s *AttendanceService) EndBreak(ctx context.Context, userID, orgID string) (*domain.AttendanceBreak, error) {
+	latest, err := s.openSession(ctx, userID, orgID, "")
+	if err != nil {
+		return nil, err
+	}
+	brk, err := s.repo.EndBreak(ctx, latest.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if brk == nil {
+		return nil, errors.New("not on break")
+	}
+	return brk, nil
+}
+
+// CreateManualAttendance records a complete session on behalf of a member.
+// General sessions are evaluated against the member's shift like a check-in.
+func (s *AttendanceService) CreateManualAttendance(ctx context.Context, actorUserID, orgID string, req *domain.ManualAttendanceRequest) (*domain.Attendance, error) {
+	if err := requireManager(ctx, s.orgRepo, orgID, actorUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.orgRepo.GetMember(ctx, orgID, req.UserID); err != nil {
+		return nil, err
+	}
+	checkOut := req.CheckOutTime
+	if err := checkSessionTimes(req.CheckInTime, &checkOut, time.Now()); err != nil {
+		return nil, err
+	}
+
+	att := &domain.Attendance{
+		UserID:       req.UserID,
+		OrgID:        orgID,
+		TaskID:       req.TaskID,
+		CheckInTime:  req.CheckInTime,
+		CheckOutTime: &checkOut,
+		Status:       domain.AttendanceStatusPresent,
+		Source:       domain.AttendanceSourceManual,
+		RecordedBy:   &actorUserID,
+		Note:         req.Note,
+	}
+
+	if req.TaskID != nil {
+		task, err := s.repo.GetTaskByID(ctx, *req.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		if task == nil || task.OrgID != orgID {
+			return nil, errors.New("task not found")
+		}
+		att.Type = "TASK"
+	} else {
+		att.Type = "GENERAL"
+		_, schedule, err := s.repo.GetMemberGroup(ctx, orgID, req.UserID, att.CheckInTime)
+		if err != nil {
+			return nil, err
+		}
+		if schedule != nil {
+			occ, status, lateMinutes, err := evaluateCheckIn(schedule, att.CheckInTime)
+			if err != nil {
+				return nil, err
+			}
+			att.ShiftApplied = shiftApplied(schedule, occ)
+			att.Status = status
+			att.LateMinutes = lateMinutes
+			allowedEarlyLeave := 0
+			if occ != nil {
+				att.ShiftDate = occ.Date
+				att.ShiftSegment = occ.Segment
+				att.ScheduledStart = &occ.Start
+				att.ScheduledEnd = &occ.End
+				allowedEarlyLeave = occ.Shift.AllowedEarlyLeaveMinutes
+			}
+			evaluateCheckOut(att, checkOut, allowedEarlyLeave)
+		}
+	}
+
+	if err := s.repo.CreateAttendance(ctx, att); err != nil {
+		return nil, err
+	}
+	setWorkedMinutes(att)
+	return att, nil
+}
+
+// EditAttendance replaces the times of a member's attendance record and
+// re-evaluates it. The record is marked MANUAL with the editor recorded.
+func (s *AttendanceService) EditAttendance(ctx context.Context, actorUserID, orgID, attendanceID string, req *domain.AttendanceEditRequest) (*domain.Attendance, error) {
+	if err := requireManager(ctx, s.orgRepo, orgID, actorUserID); err != nil {
+		return nil, err
+	}
+	att, err := s.repo.GetAttendanceByID(ctx, attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if att == nil || att.OrgID != orgID {
+		return nil, errors.New("attendance not found")
+	}
+	checkOut := req.CheckOutTime
+	if err := checkSessionTimes(req.CheckInTime, &checkOut, time.Now()); err != nil {
+		return nil, err
+	}
+
+	att.CheckInTime = req.CheckInTime
+	att.CheckOutTime = &checkOut
+	att.Source = domain.AttendanceSourceManual
+	att.RecordedBy = &actorUserID
+	att.Note = req.Note
+	if err := rescoreAttendance(ctx, s.repo, att); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateAttendance(ctx, att); err != nil {
+		return nil, err
+	}
+	setWorkedMinutes(att)
+	return att, nil
+}
+
+// openSession returns the given open session of the user, or their open
+// session in the organization. With neither given it falls back to the
+// user's only open session.
+func (s *AttendanceService) openSession(ctx context.Context, userID, orgID, attendanceID string) (*domain.Attendance, error) {
+	if attendanceID != "" {
+		att, err := s.repo.GetAttendanceByID(ctx, attendanceID)
+		if err != nil {
+			return nil, err
+		}
+		if att == nil || att.UserID != userID || (orgID != "" && att.OrgID != orgID) {
+			return nil, errors.New("attendance not found")
+		}
+		if att.CheckOutTime != nil || att.Status == domain.AttendanceStatusAbsent {
+			return nil, errors.New("not checked in")
+		}
+		return att, nil
+	}
+
+	sessions, err := s.repo.ListOpenAttendance(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	switch len(sessions) {
+	case 0:
+		return nil, errors.New("not checked in")
+	case 1:
+		return sessions[0], nil
+	default:
+		return nil, errors.New("organization_id is required when checked in to more than one organization")
+	}
+}
+
+// verifyCheckpointToken checks that a scanned token was signed by one of the
+// organization's checkpoints for the current rotation window, or the previous
+// one to allow for the time taken to scan and submit. It returns the
+// checkpoint and the token's window.
+func (s *AttendanceService) verifyCheckpointToken(ctx context.Context, orgID, token string, now time.Time) (*domain.Checkpoint, int64, error) {
+	checkpointID, window, err := qrtoken.Parse(token)
+	if err != nil {
+		return nil, 0, errors.New("invalid qr token")
+	}
+	checkpoint, err := s.repo.GetCheckpointByID(ctx, checkpointID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if checkpoint == nil || checkpoint.OrgID != orgID || !qrtoken.Verify(checkpoint.Secret, token) {
+		return nil, 0, errors.New("invalid qr token")
+	}
+
+	current := qrtoken.Window(now, time.Duration(checkpoint.RotationSeconds)*time.Second)
+	if window != current && window != current-1 {
+		return nil, 0, errors.New("qr token expired")
+	}
+	return checkpoint, window, nil
+}
+
+// maxBoundaryTolerance caps how far outside a boundary a location may be
+// while the device's reported accuracy still allows it to be inside.
+const maxBoundaryTolerance = 50.0
+
+// geofence is the area a check-in or check-out location must fall within:
+// a circle, or a polygon boundary when one is set.
+type geofence struct {
+	lat, long    float64
+	radiusMeters int
+	boundary     geo.MultiPolygon
+	mode         string // WARN or REJECT
+	place        string // What the area is, for flag reasons
+}
+
+// newBoundaryGeofence returns the geofence for the area's boundary, or for
+// the circle when it has none.
+func newBoundaryGeofence(lat, long float64, radiusMeters int, boundary *domain.Boundary, mode, place string) *geofence {
+	fence := &geofence{lat: lat, long: long, radiusMeters: radiusMeters, mode: mode, place: place}
+	if boundary != nil {
+		// Validated when saved
+		fence.boundary, _ = geo.ParseBoundary(boundary.Type, boundary.Coordinates)
+	}
+	return fence
+}
+
+// geofenceFor returns the geofence for a task session, or the organization's
+// for a general session (task nil), centered on the session's site if it has
+// one. It returns nil when none is enforced.
+func (s *AttendanceService) geofenceFor(ctx context.Context, orgID string, task *domain.Task, siteID *string) (*geofence, error) {
+	if task != nil {
+		if !task.GeofencingEnabled {
+			return nil, nil
+		}
+		return newBoundaryGeofence(task.Latitude, task.Longitude, task.RadiusMeters, task.Boundary, domain.GeofenceModeReject, "task location"), nil
+	}
+
+	org, err := s.orgRepo.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if siteID != nil {
+		site, err := s.orgRepo.GetSiteByID(ctx, *siteID)
+		if err != nil {
+			return nil, err
+		}
+		if site != nil {
+			return siteGeofence(org, site), nil
+		}
+	}
+	return orgGeofence(org), nil
+}
+
+// orgGeofence returns the organization's geofence, or nil when none is enforced.
+func orgGeofence(org *domain.Organization) *geofence {
+	if org.GeofenceMode == "" || org.GeofenceMode == domain.GeofenceModeOff || org.GeofenceRadiusMeters <= 0 {
+		return nil
+	}
+	return &geofence{lat: org.DefaultLocationLat, long: org.DefaultLocationLong, radiusMeters: org.GeofenceRadiusMeters, mode: org.GeofenceMode, place: "default location"}
+}
+
+// siteGeofence returns the organization's geofence moved to the site, or nil
+// when none is enforced.
+func siteGeofence(org *domain.Organization, site *domain.Site) *geofence {
+	if org.GeofenceMode == "" || org.GeofenceMode == domain.GeofenceModeOff {
+		return nil
+	}
+	return newBoundaryGeofence(site.Latitude, site.Longitude, site.RadiusMeters, site.Boundary, org.GeofenceMode, "site "+site.Name)
+}
+
+// assignedSite returns the site the member's group works at, falling back to
+// the shift's site, or nil if neither has one. The group is nil for members
+// scheduled only through assignments.
+func (s *AttendanceService) assignedSite(ctx context.Context, orgID string, group *domain.Group, shift *domain.Shift) (*domain.Site, error) {
+	var siteID *string
+	if group != nil {
+		siteID = group.SiteID
+	}
+	if siteID == nil && shift != nil {
+		siteID = shift.SiteID
+	}
+	if siteID == nil {
+		return nil, nil
+	}
+	site, err := s.orgRepo.GetSiteByID(ctx, *siteID)
+	if err != nil || site == nil || site.OrgID != orgID {
+		return nil, err
+	}
+	return site, nil
+}
+
+// nearestSite returns the site closest to the location, and whether the
+// location is within its area. Sites containing the location are preferred
+// over closer ones that do not; distances are measured to each site's edge.
+func nearestSite(sites []*domain.Site, lat, long float64, accuracy *float64) (*domain.Site, bool) {
+	var nearest *domain.Site
+	var nearestDistance float64
+	inside := false
+	for _, site := range sites {
+		fence := newBoundaryGeofence(site.Latitude, site.Longitude, site.RadiusMeters, site.Boundary, "", "")
+		distance, contains := fence.measure(lat, long, accuracy)
+		if fence.boundary == nil {
+			distance = math.Max(0, distance-float64(site.RadiusMeters))
+		}
+		if nearest == nil || (contains && !inside) || (contains == inside && distance < nearestDistance) {
+			nearest, nearestDistance, inside = site, distance, contains
+		}
+	}
+	return nearest, inside
+}
+
+// measure returns the location's distance from the center, or from the
+// boundary's edge when the fence has one, and whether it is inside. A location
+// just outside a boundary counts as inside when the device's reported
+// accuracy, up to maxBoundaryTolerance, covers the gap.
+func (g *geofence) measure(lat, long float64, accuracy *float64) (distance float64, inside bool) {
+	if g.boundary == nil {
+		distance = geo.Distance(lat, long, g.lat, g.long)
+		return distance, distance <= float64(g.radiusMeters)
+	}
+	tolerance := 0.0
+	if accuracy != nil {
+		tolerance = math.Min(*accuracy, maxBoundaryTolerance)
+	}
+	distance = g.boundary.DistanceOutside(lat, long)
+	return distance, distance <= tolerance
+}
+
+// check measures a location against the geofence. Outside it, REJECT mode
+// returns a GeofenceError and WARN mode reports outside as true.
+func (g *geofence) check(lat, long float64, accuracy *float64) (distance float64, outside bool, err error) {
+	distance, inside := g.measure(lat, long, accuracy)
+	if inside {
+		return distance, false, nil
+	}
+	if g.mode == domain.GeofenceModeReject {
+		if g.boundary != nil {
+			return distance, true, &domain.GeofenceError{DistanceMeters: distance}
+		}
+		return distance, true, &domain.GeofenceError{DistanceMeters: distance, RadiusMeters: g.radiusMeters}
+	}
+	return distance, true, nil
+}
+
+// describe says how far outside the geofence a location is, for flag reasons.
+func (g *geofence) describe(distance float64) string {
+	if g.boundary != nil {
+		return fmt.Sprintf("%.0fm outside the boundary of %s", distance, g.place)
+	}
+	return fmt.Sprintf("%.0fm from %s, allowed %dm", distance, g.place, g.radiusMeters)
+}
+
+// checkInGeofence applies the task's geofence according to its enforcement
+// mode. General check-ins go through checkInOrgPolicy instead.
+func (s *AttendanceService) checkInGeofence(ctx context.Context, orgID string, task *domain.Task, req *domain.Attendance) error {
+	fence, err := s.geofenceFor(ctx, orgID, task, nil)
+	if err != nil || fence == nil {
+		return err
+	}
+
+	distance, outside, err := fence.check(req.LocationLat, req.LocationLong, req.LocationAccuracy)
+	req.DistanceMeters = &distance
+	if err != nil {
+		return err
+	}
+	if outside {
+		req.Flag("outside organization geofence: " + fence.describe(distance))
+	}
+	return nil
+}
+
+// policyFailure is a check-in that failed one of the organization's
+// location checks.
+type policyFailure struct {
+	violation *domain.CheckInViolation // Recorded when the check refuses the check-in
+	err       error                    // Set in REJECT mode
+	reason    string                   // Flag reason otherwise
+}
+
+// checkInOrgPolicy applies the organization's geofence and network allowlist
+// to a general GPS check-in. The geofence is centered on the member's
+// assigned site or, without one, on the nearest of the organization's sites,
+// whose radius then also decides where the check-in is recorded; only
+// organizations without sites use their default location. Under the ANY
+// location rule, passing either enforced check is enough; otherwise each
+// applies on its own. Refused check-ins are recorded as violations.
+func (s *AttendanceService) checkInOrgPolicy(ctx context.Context, orgID string, site *domain.Site, req *domain.Attendance) error {
+	org, err := s.orgRepo.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	fence := orgGeofence(org)
+	if site == nil {
+		sites, err := s.orgRepo.ListSites(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		if nearest, inside := nearestSite(sites, req.LocationLat, req.LocationLong, req.LocationAccuracy); nearest != nil {
+			if inside {
+				req.SiteID = &nearest.ID
+			}
+			site = nearest
+		}
+	}
+	if site != nil {
+		fence = siteGeofence(org, site)
+	}
+
+	var failures []policyFailure
+	passed := false
+	if fence != nil {
+		distance, outside, err := fence.check(req.LocationLat, req.LocationLong, req.LocationAccuracy)
+		req.DistanceMeters = &distance
+		if outside {
+			failures = append(failures, policyFailure{
+				violation: &domain.CheckInViolation{Kind: domain.ViolationKindGeofence, DistanceMeters: &distance},
+				err:       err,
+				reason:    "outside organization geofence: " + fence.describe(distance),
+			})
+		} else {
+			passed = true
+		}
+	}
+	if network := orgNetworkPolicy(org); network != nil {
+		switch {
+		case req.Offline:
+			// The address of the sync request says nothing about where the
+			// device was, so the check-in is flagged but never refused
+			failures = append(failures, policyFailure{reason: "network not verified for offline check-in"})
+		case network.allows(req.ClientIP):
+			passed = true
+		default:
+			failure := policyFailure{
+				violation: &domain.CheckInViolation{Kind: domain.ViolationKindNetwork},
+				reason:    fmt.Sprintf("outside allowed networks: client address %s", req.ClientIP),
+			}
+			if network.mode == domain.GeofenceModeReject {
+				failure.err = &domain.NetworkError{ClientIP: req.ClientIP}
+			}
+			failures = append(failures, failure)
+		}
+	}
+	if passed && org.LocationRule == domain.LocationRuleAny {
+		return nil
+	}
+
+	var rejection *checkInRejection
+	for _, f := range failures {
+		if f.err == nil {
+			continue
+		}
+		v := f.violation
+		v.OrgID, v.UserID, v.ClientIP = orgID, req.UserID, req.ClientIP
+		v.LocationLat, v.LocationLong = req.LocationLat, req.LocationLong
+		if rejection == nil {
+			rejection = &checkInRejection{err: f.err}
+		}
+		rejection.violations = append(rejection.violations, v)
+	}
+	if rejection != nil {
+		return rejection
+	}
+	for _, f := range failures {
+		req.Flag(f.reason)
+	}
+	return nil
+}
+
+// checkInRejection is a check-in refused by the organization's location
+// policy. It carries the violations to record, which the caller writes once
+// any transaction the check-in ran in has been rolled back.
+type checkInRejection struct {
+	err        error
+	violations []*domain.CheckInViolation
+}
+
+func (e *checkInRejection) Error() string { return e.err.Error() }
+
+func (e *checkInRejection) Unwrap() error { return e.err }
+
+// recordViolations stores the violations of a check-in refused with err, if
+// it was refused by the location policy.
+func (s *AttendanceService) recordViolations(ctx context.Context, err error) error {
+	var rejection *checkInRejection
+	if !errors.As(err, &rejection) {
+		return nil
+	}
+	for _, v := range rejection.violations {
+		if err := s.repo.CreateCheckInViolation(ctx, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkOutGeofence applies the session's check-in geofence to the check-out
+// location. Without a location, REJECT mode refuses the check-out and WARN
+// mode flags it.
+func (s *AttendanceService) checkOutGeofence(ctx context.Context, att *domain.Attendance, req *domain.CheckOutRequest) error {
+	var task *domain.Task
+	if att.TaskID != nil {
+		var err error
+		task, err = s.repo.GetTaskByID(ctx, *att.TaskID)
+		if err != nil {
+			return err
+		}
+		if task == nil {
+			return errors.New("task not found")
+		}
+	}
+	fence, err := s.geofenceFor(ctx, att.OrgID, task, att.SiteID)
+	if err != nil || fence == nil {
+		return err
+	}
+
+	if req.Latitude == nil || req.Longitude == nil {
+		if fence.mode == domain.GeofenceModeReject {
+			return errors.New("location is required to check out")
+		}
+		att.Flag("checked out without a location")
+		return nil
+	}
+
+	distance, outside, err := fence.check(*req.Latitude, *req.Longitude, nil)
+	att.CheckOutDistanceMeters = &distance
+	if err != nil {
+		return err
+	}
+	if outside {
+		att.Flag("checked out outside organization geofence: " + fence.describe(distance))
+	}
+	return nil
+}
+
+// MarkAbsences records ABSENT attendance for scheduled members who did not
+// check in to a shift instance that ended within the lookback window before
+// now. Each date is held to the member's schedule assignment covering it,
+// otherwise to their group's shift or rotation. It is safe to run repeatedly and returns the number of records created.
+func (s *AttendanceService) MarkAbsences(ctx context.Context, now time.Time, lookback time.Duration) (int, error) {
+	// Start a day early so overnight instances ending inside the window are included.
+	since := now.Add(-lookback - 24*time.Hour)
+	members, err := s.repo.ListScheduledMembers(ctx, since)
+	if err != nil {
+		return 0, err
+	}
+
+	// Dates each member's assignments cover, where their group schedule does not apply
+	assigned := make(map[string][]*domain.ScheduledMember)
+	for _, m := range members {
+		if m.EffectiveFrom != "" {
+			key := m.OrgID + "/" + m.UserID
+			assigned[key] = append(assigned[key], m)
+		}
+	}
+
+	resolvers := make(map[string]*shiftResolver)
+	created := 0
+	var errs []error
+	for _, m := range members {
+		var key string
+		if m.Schedule.Rotation != nil {
+			key = "rotation " + m.Schedule.Rotation.ID
+		} else {
+			key = "shift " + m.Schedule.Shift.ID
+		}
+		resolver, ok := resolvers[key]
+		if !ok {
+			resolver, err = newScheduleResolver(&m.Schedule)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+			resolvers[key] = resolver
+		}
+
+		for _, occ := range resolver.occurrencesBetween(since.In(resolver.loc), now.In(resolver.loc)) {
+			if occ.End.After(now) || occ.End.Before(now.Add(-lookback)) || occ.Start.Before(m.JoinedAt) {
+				continue
+			}
+			if m.EffectiveFrom != "" && !coversDate(m, occ.Date) {
+				continue
+			}
+			if m.EffectiveFrom == "" && slices.ContainsFunc(assigned[m.OrgID+"/"+m.UserID], func(a *domain.ScheduledMember) bool {
+				return coversDate(a, occ.Date)
+			}) {
+				continue
+			}
+			start, end := occ.Start, occ.End
+			absence := &domain.Attendance{
+				UserID:         m.UserID,
+				OrgID:          m.OrgID,
+				CheckInTime:    start,
+				Status:         domain.AttendanceStatusAbsent,
+				Type:           "GENERAL",
+				ShiftApplied:   occ.Shift.Name,
+				ShiftDate:      occ.Date,
+				ShiftSegment:   occ.Segment,
+				ScheduledStart: &start,
+				ScheduledEnd:   &end,
+			}
+			inserted, err := s.repo.CreateAbsence(ctx, absence)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("user %s on %s: %w", m.UserID, occ.Date, err))
+				continue
+			}
+			if inserted {
+				created++
+			}
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// coversDate reports whether the schedule assignment is in effect on the date.
+func coversDate(m *domain.ScheduledMember, date string) bool {
+	return date >= m.EffectiveFrom && (m.EffectiveTo == nil || date <= *m.EffectiveTo)
+}
+
+// AutoCloseSessions checks out sessions the member forgot to close. Sessions
+// attributed to a shift are closed at the shift end once afterShiftEnd has
+// passed; other sessions are closed at check-in plus maxSession. It returns
+// the number of sessions closed.
+func (s *AttendanceService) AutoCloseSessions(ctx context.Context, now time.Time, afterShiftEnd, maxSession time.Duration) (int, error) {
+	sessions, err := s.repo.ListStaleSessions(ctx, now.Add(-afterShiftEnd), now.Add(-maxSession))
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	var errs []error
+	for _, att := range sessions {
+		closeAt := att.CheckInTime.Add(maxSession)
+		if att.ScheduledEnd != nil {
+			closeAt = *att.ScheduledEnd
+			if closeAt.Before(att.CheckInTime) {
+				closeAt = att.CheckInTime
+			}
+		}
+		att.CheckOutTime = &closeAt
+		att.AutoClosed = true
+
+		// A session is closed together with its open break, so a failure
+		// leaves both open for the next run
+		var ok bool
+		err := s.txMgr.RunInTx(ctx, func(ctx context.Context) error {
+			var err error
+			ok, err = s.repo.CloseAttendance(ctx, att)
+			if err != nil || !ok {
+				return err
+			}
+			_, err = s.repo.EndBreak(ctx, att.ID, closeAt)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("attendance %s: %w", att.ID, err))
+			continue
+		}
+		if ok {
+			closed++
+		}
+	}
+	return closed, errors.Join(errs...)
+}
+
+// rescoreAttendance recomputes the status, lateness, overtime and early leave
+// of a corrected or manually edited record against its scheduled shift
+// instance, using the tolerances of the member's shift in effect on its date.
+// Records without a schedule keep their status, except that a corrected
+// absence becomes PRESENT.
+func rescoreAttendance(ctx context.Context, repo port.AttendanceRepository, att *domain.Attendance) error {
+	if att.ScheduledStart == nil {
+		if att.Status == domain.AttendanceStatusAbsent {
+			att.Status = domain.AttendanceStatusPresent
+		}
+		return nil
+	}
+
+	_, schedule, err := repo.GetMemberGroup(ctx, att.OrgID, att.UserID, *att.ScheduledStart)
+	if err != nil {
+		return err
+	}
+	shift, err := shiftAt(schedule, *att.ScheduledStart)
+	if err != nil {
+		return err
+	}
+	grace, earlyLeave := 0, 0
+	if shift != nil {
+		grace = shift.AllowedLateMinutes
+		earlyLeave = shift.AllowedEarlyLeaveMinutes
+	}
+
+	att.Status = domain.AttendanceStatusPresent
+	att.LateMinutes = 0
+	if late := att.CheckInTime.Sub(*att.ScheduledStart); late > time.Duration(grace)*time.Minute {
+		att.Status = domain.AttendanceStatusLate
+		att.LateMinutes = int(late.Minutes())
+	}
+
+	if att.CheckOutTime != nil {
+		evaluateCheckOut(att, *att.CheckOutTime, earlyLeave)
+	}
+	return nil
+}
+
+// checkSessionTimes checks that a session's times are not in the future and
+// that it ends after it starts.
+func checkSessionTimes(checkIn time.Time, checkOut *time.Time, now time.Time) error {
+	if checkIn.After(now) || (checkOut != nil && checkOut.After(now)) {
+		return errors.New("attendance times cannot be in the future")
+	}
+	if checkOut != nil && !checkOut.After(checkIn) {
+		return errors.New("check-out time must be after check-in time")
+	}
+	return nil
+}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// ListMyAttendance returns a page of the user's own attendance records.
+func (s *AttendanceService) ListMyAttendance(ctx context.Context, userID string, filter domain.AttendanceFilter, cursor string) (*domain.AttendancePage, error) {
+	filter.UserID = userID
+	if err := applyCursor(&filter, cursor); err != nil {
+		return nil, err
+	}
+
+	records, err := s.repo.ListAttendance(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.AttendancePage{}
+	page.Items, page.NextCursor = paginate(records, filter.Limit, func(att *domain.Attendance) *domain.Attendance { return att })
+	return page, nil
+}
+
+// ListCheckInViolations returns the organization's latest check-ins refused
+// by its geofence or network allowlist (Owner/Manager only).
+func (s *AttendanceService) ListCheckInViolations(ctx context.Context, actorUserID, orgID string, limit int) ([]*domain.CheckInViolation, error) {
+	if err := requireManager(ctx, s.orgRepo, orgID, actorUserID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+	return s.repo.ListCheckInViolations(ctx, orgID, limit)
+}
+
+// ListTeamAttendance returns a page of attendance records for the
+// organization's members. Only owners and managers may list them.
+func (s *AttendanceService) ListTeamAttendance(ctx context.Context, requesterUserID, orgID string, filter domain.AttendanceFilter, cursor string) (*domain.TeamAttendancePage, error) {
+	if err := requireManager(ctx, s.orgRepo, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+
+	filter.OrgID = orgID
+	if err := applyCursor(&filter, cursor); err != nil {
+		return nil, err
+	}
+
+	records, err := s.repo.ListOrgAttendance(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.TeamAttendancePage{}
+	page.Items, page.NextCursor = paginate(records, filter.Limit, func(d *domain.AttendanceDetail) *domain.Attendance { return &d.Attendance })
+	return page, nil
+}
+
+// paginate trims the extra record fetched by applyCursor, returning the page
+// items and the cursor for the next page, and computes worked durations.
+func paginate[T any](records []T, limit int, attendance func(T) *domain.Attendance) ([]T, string) {
+	var next string
+	if len(records) >= limit {
+		records = records[:limit-1]
+		last := attendance(records[len(records)-1])
+		next = encodeCursor(last.CheckInTime, last.ID)
+	}
+	if records == nil {
+		records = []T{}
+	}
+	for _, record := range records {
+		setWorkedMinutes(attendance(record))
+	}
+	return records, next
+}
+
+// applyCursor decodes the page cursor into the filter and normalizes the page
+// size. The limit is raised by one so callers can tell whether another page exists.
+func applyCursor(filter *domain.AttendanceFilter, cursor string) error {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+	filter.Limit++
+
+	if cursor == "" {
+		return nil
+	}
+	t, id, err := decodeCursor(cursor)
 	if err != nil {
 		return err
 	}
-	if latest == nil || latest.CheckOutTime != nil {
-		return errors.New("not checked in")
+	filter.CursorTime = &t
+	filter.CursorID = id
+	return nil
+}
+
+func encodeCursor(t time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(t.UTC().Format(time.RFC3339Nano) + "|" + id))
+}
+
+func decodeCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || !validator.IsValid(id, "uuid") {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid cursor")
 	}
+	return t, id, nil
+}
 
-	now := time.Now()
-	latest.CheckOutTime = &now
-	return s.repo.UpdateAttendance(ctx, latest)
+// setWorkedMinutes fills in the worked duration of a closed session, less
+// unpaid breaks.
+func setWorkedMinutes(att *domain.Attendance) {
+	if att.CheckOutTime == nil {
+		return
+	}
+	worked := int(att.CheckOutTime.Sub(att.CheckInTime).Minutes()) - att.UnpaidBreakMinutes
+	att.WorkedMinutes = &worked
 }
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+)
+
+// fakeAttendanceRepository keeps the records the background jobs read and
+// write in memory. Methods the jobs do not use are left to the embedded nil
+// interface and panic if called.
+type fakeAttendanceRepository struct {
+	port.AttendanceRepository
+	members     []*domain.ScheduledMember
+	records     []*domain.Attendance
+	stale       []*domain.Attendance
+	closed      map[string]time.Time // Check-out time by attendance ID
+	breaksEnded map[string]time.Time // Break end time by attendance ID
+	endBreakErr error
+
+	staleShiftEndedBefore, staleCheckedInBefore time.Time
+}
+
+func (r *fakeAttendanceRepository) ListScheduledMembers(ctx context.Context, since time.Time) ([]*domain.ScheduledMember, error) {
+	return r.members, nil
+}
+
+// CreateAbsence mirrors the SQL: no row when the member already has a record
+// for the instance's date and segment or one that began while it ran.
+func (r *fakeAttendanceRepository) CreateAbsence(ctx context.Context, att *domain.Attendance) (bool, error) {
+	for _, existing := range r.records {
+		if existing.UserID != att.UserID || existing.OrgID != att.OrgID {
+			continue
+		}
+		sameInstance := existing.ShiftDate == att.ShiftDate && existing.ShiftSegment == att.ShiftSegment
+		during := !existing.CheckInTime.Before(att.CheckInTime) && existing.CheckInTime.Before(*att.ScheduledEnd)
+		if sameInstance || during {
+			return false, nil
+		}
+	}
+	r.records = append(r.records, att)
+	return true, nil
+}
+
+func (r *fakeAttendanceRepository) ListStaleSessions(ctx context.Context, shiftEndedBefore, checkedInBefore time.Time) ([]*domain.Attendance, error) {
+	r.staleShiftEndedBefore, r.staleCheckedInBefore = shiftEndedBefore, checkedInBefore
+	return r.stale, nil
+}
+
+func (r *fakeAttendanceRepository) CloseAttendance(ctx context.Context, att *domain.Attendance) (bool, error) {
+	if _, ok := r.closed[att.ID]; ok {
+		return false, nil
+	}
+	r.closed[att.ID] = *att.CheckOutTime
+	return true, nil
+}
+
+func (r *fakeAttendanceRepository) EndBreak(ctx context.Context, attendanceID string, at time.Time) (*domain.AttendanceBreak, error) {
+	if r.endBreakErr != nil {
+		return nil, r.endBreakErr
+	}
+	r.breaksEnded[attendanceID] = at
+	return &domain.AttendanceBreak{AttendanceID: attendanceID}, nil
+}
+
+// fakeTxManager rolls back the fake repository's session and break writes
+// when the transaction fails.
+type fakeTxManager struct {
+	repo *fakeAttendanceRepository
+}
+
+func (m *fakeTxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	closed, breaksEnded := maps.Clone(m.repo.closed), maps.Clone(m.repo.breaksEnded)
+	err := fn(ctx)
+	if err != nil {
+		m.repo.closed, m.repo.breaksEnded = closed, breaksEnded
+	}
+	return err
+}
+
+func newJobTestService() (*AttendanceService, *fakeAttendanceRepository) {
+	repo := &fakeAttendanceRepository{closed: map[string]time.Time{}, breaksEnded: map[string]time.Time{}}
+	return NewAttendanceService(repo, nil, &fakeTxManager{repo: repo}, RiskPolicy{}), repo
+}
+
+func berlinShift(start, end string) *domain.Shift {
+	return &domain.Shift{
+		ID:          "shift-1",
+		Name:        "Shift",
+		StartTime:   start,
+		EndTime:     end,
+		Timezone:    "Europe/Berlin",
+		WorkingDays: []string{"MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"},
+	}
+}
+
+func TestMarkAbsences(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	joined := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		shift         *domain.Shift
+		now           time.Time
+		existing      []*domain.Attendance
+		expectedDates []string
+		expectedStart time.Time
+		expectedHours float64
+	}{
+		{
+			name:          "Overnight Shift",
+			shift:         berlinShift("22:00", "06:00"),
+			now:           time.Date(2026, 3, 11, 7, 0, 0, 0, berlin),
+			expectedDates: []string{"2026-03-10"},
+			expectedStart: time.Date(2026, 3, 10, 22, 0, 0, 0, berlin),
+			expectedHours: 8,
+		},
+		{
+			name:          "Overnight Shift Into Spring Forward",
+			shift:         berlinShift("22:00", "06:00"),
+			now:           time.Date(2026, 3, 29, 7, 0, 0, 0, berlin),
+			expectedDates: []string{"2026-03-28"},
+			expectedStart: time.Date(2026, 3, 28, 21, 0, 0, 0, time.UTC),
+			expectedHours: 7,
+		},
+		{
+			name:          "Overnight Shift Into Fall Back",
+			shift:         berlinShift("22:00", "06:00"),
+			now:           time.Date(2026, 10, 25, 7, 0, 0, 0, berlin),
+			expectedDates: []string{"2026-10-24"},
+			expectedStart: time.Date(2026, 10, 24, 20, 0, 0, 0, time.UTC),
+			expectedHours: 9,
+		},
+		{
+			name:          "Day Shift On Spring Forward Day",
+			shift:         berlinShift("09:00", "17:00"),
+			now:           time.Date(2026, 3, 29, 18, 0, 0, 0, berlin),
+			expectedDates: []string{"2026-03-29"},
+			expectedStart: time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC),
+			expectedHours: 8,
+		},
+		{
+			name:  "Session Already Exists",
+			shift: berlinShift("22:00", "06:00"),
+			now:   time.Date(2026, 3, 11, 7, 0, 0, 0, berlin),
+			existing: []*domain.Attendance{{
+				UserID:      "user-1",
+				OrgID:       "org-1",
+				CheckInTime: time.Date(2026, 3, 10, 22, 20, 0, 0, berlin),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newJobTestService()
+			repo.members = []*domain.ScheduledMember{{OrgID: "org-1", UserID: "user-1", JoinedAt: joined, Schedule: domain.Schedule{Shift: tt.shift}}}
+			repo.records = tt.existing
+
+			created, err := svc.MarkAbsences(context.Background(), tt.now, 2*time.Hour)
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.expectedDates), created)
+
+			var absences []*domain.Attendance
+			for _, att := range repo.records {
+				if att.Status == domain.AttendanceStatusAbsent {
+					absences = append(absences, att)
+				}
+			}
+			if !assert.Len(t, absences, len(tt.expectedDates)) {
+				return
+			}
+			for i, date := range tt.expectedDates {
+				absence := absences[i]
+				assert.Equal(t, date, absence.ShiftDate)
+				assert.True(t, tt.expectedStart.Equal(*absence.ScheduledStart), "scheduled start %s, want %s", absence.ScheduledStart, tt.expectedStart)
+				assert.Equal(t, tt.expectedHours, absence.ScheduledEnd.Sub(*absence.ScheduledStart).Hours())
+			}
+
+			// A second run finds the absence already recorded
+			created, err = svc.MarkAbsences(context.Background(), tt.now, 2*time.Hour)
+			assert.NoError(t, err)
+			assert.Zero(t, created)
+		})
+	}
+}
+
+func TestAutoCloseSessions(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	shiftEnd := now.Add(-3 * time.Hour)
+	lateCheckIn := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name           string
+		stale          []*domain.Attendance
+		endBreakErr    error
+		expectedClosed map[string]time.Time
+		expectErr      bool
+	}{
+		{
+			name: "Closes At Shift End Or Session Limit",
+			stale: []*domain.Attendance{
+				{ID: "att-shift", CheckInTime: now.Add(-10 * time.Hour), ScheduledEnd: &shiftEnd},
+				{ID: "att-task", CheckInTime: now.Add(-20 * time.Hour)},
+				{ID: "att-late", CheckInTime: lateCheckIn, ScheduledEnd: &shiftEnd}, // Checked in after the shift ended
+			},
+			expectedClosed: map[string]time.Time{
+				"att-shift": shiftEnd,
+				"att-task":  now.Add(-20 * time.Hour).Add(16 * time.Hour),
+				"att-late":  lateCheckIn,
+			},
+		},
+		{
+			name:           "Failed Break End Leaves Session Open",
+			stale:          []*domain.Attendance{{ID: "att-shift", CheckInTime: now.Add(-10 * time.Hour), ScheduledEnd: &shiftEnd}},
+			endBreakErr:    errors.New("connection reset"),
+			expectedClosed: map[string]time.Time{},
+			expectErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newJobTestService()
+			repo.stale = tt.stale
+			repo.endBreakErr = tt.endBreakErr
+
+			closed, err := svc.AutoCloseSessions(context.Background(), now, 2*time.Hour, 16*time.Hour)
+			assert.Equal(t, tt.expectErr, err != nil, err)
+			assert.Equal(t, len(tt.expectedClosed), closed)
+			assert.True(t, now.Add(-2*time.Hour).Equal(repo.staleShiftEndedBefore))
+			assert.True(t, now.Add(-16*time.Hour).Equal(repo.staleCheckedInBefore))
+
+			assert.Len(t, repo.closed, len(tt.expectedClosed))
+			for id, at := range tt.expectedClosed {
+				assert.True(t, at.Equal(repo.closed[id]), "%s closed at %s, want %s", id, repo.closed[id], at)
+				// The open break ends when the session is closed
+				assert.True(t, at.Equal(repo.breaksEnded[id]), "%s break ended at %s, want %s", id, repo.breaksEnded[id], at)
+			}
+			if tt.expectErr {
+				assert.Empty(t, repo.breaksEnded)
+			}
+		})
+	}
+}
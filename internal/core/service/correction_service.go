@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+)
+
+type CorrectionService struct {
+	repo    port.CorrectionRepository
+	attRepo port.AttendanceRepository
+	orgRepo port.OrgRepository
+	txMgr   port.TransactionManager
+}
+
+func NewCorrectionService(repo port.CorrectionRepository, attRepo port.AttendanceRepository, orgRepo port.OrgRepository, txMgr port.TransactionManager) *CorrectionService {
+	return &CorrectionService{repo: repo, attRepo: attRepo, orgRepo: orgRepo, txMgr: txMgr}
+}
+
+// SubmitCorrection files a request to change the times of one of the user's
+// own attendance records. A missed shift (ABSENT record) needs both times.
+func (s *CorrectionService) SubmitCorrection(ctx context.Context, userID, attendanceID string, req *domain.CorrectionRequest) (*domain.AttendanceCorrection, error) {
+	if req.CheckInTime == nil && req.CheckOutTime == nil {
+		return nil, errors.New("check_in_time or check_out_time is required")
+	}
+
+	att, err := s.attRepo.GetAttendanceByID(ctx, attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if att == nil || att.UserID != userID {
+		return nil, errors.New("attendance not found")
+	}
+	if _, _, err := correctedTimes(att, req.CheckInTime, req.CheckOutTime, time.Now()); err != nil {
+		return nil, err
+	}
+
+	correction := &domain.AttendanceCorrection{
+		AttendanceID:          att.ID,
+		UserID:                userID,
+		OrgID:                 att.OrgID,
+		RequestedCheckInTime:  req.CheckInTime,
+		RequestedCheckOutTime: req.CheckOutTime,
+		OriginalCheckInTime:   att.CheckInTime,
+		OriginalCheckOutTime:  att.CheckOutTime,
+		OriginalStatus:        att.Status,
+		Reason:                req.Reason,
+		Status:                domain.CorrectionStatusPending,
+	}
+	if err := s.repo.CreateCorrection(ctx, correction); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// ListCorrections returns the organization's correction requests, newest
+// first. Only owners and managers may list them.
+func (s *CorrectionService) ListCorrections(ctx context.Context, requesterUserID, orgID string, filter domain.CorrectionFilter) ([]*domain.AttendanceCorrection, error) {
+	if err := requireManager(ctx, s.orgRepo, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	filter.OrgID = orgID
+	corrections, err := s.repo.ListCorrections(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if corrections == nil {
+		corrections = []*domain.AttendanceCorrection{}
+	}
+	return corrections, nil
+}
+
+// ApproveCorrection applies the requested times to the attendance record and
+// re-evaluates its status in a single transaction. The values it replaces
+// are stored on the correction.
+func (s *CorrectionService) ApproveCorrection(ctx context.Context, reviewerUserID, orgID, correctionID, note string) (*domain.AttendanceCorrection, error) {
+	correction, err := s.getPendingCorrection(ctx, reviewerUserID, orgID, correctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txMgr.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		att, err := s.attRepo.GetAttendanceByID(ctx, correction.AttendanceID)
+		if err != nil {
+			return err
+		}
+		if att == nil {
+			return errors.New("attendance not found")
+		}
+		checkIn, checkOut, err := correctedTimes(att, correction.RequestedCheckInTime, correction.RequestedCheckOutTime, now)
+		if err != nil {
+			return err
+		}
+
+		correction.OriginalCheckInTime = att.CheckInTime
+		correction.OriginalCheckOutTime = att.CheckOutTime
+		correction.OriginalStatus = att.Status
+		markReviewed(correction, domain.CorrectionStatusApproved, reviewerUserID, note, now)
+		ok, err := s.repo.ReviewCorrection(ctx, correction)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("correction already reviewed")
+		}
+
+		att.CheckInTime = checkIn
+		att.CheckOutTime = checkOut
+		if err := rescoreAttendance(ctx, s.attRepo, att); err != nil {
+			return err
+		}
+		return s.attRepo.UpdateAttendance(ctx, att)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// RejectCorrection closes the request without touching the attendance record.
+func (s *CorrectionService) RejectCorrection(ctx context.Context, reviewerUserID, orgID, correctionID, note string) (*domain.AttendanceCorrection, error) {
+	correction, err := s.getPendingCorrection(ctx, reviewerUserID, orgID, correctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	markReviewed(correction, domain.CorrectionStatusRejected, reviewerUserID, note, time.Now())
+	ok, err := s.repo.ReviewCorrection(ctx, correction)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("correction already reviewed")
+	}
+	return correction, nil
+}
+
+func (s *CorrectionService) getPendingCorrection(ctx context.Context, reviewerUserID, orgID, correctionID string) (*domain.AttendanceCorrection, error) {
+	if err := requireManager(ctx, s.orgRepo, orgID, reviewerUserID); err != nil {
+		return nil, err
+	}
+	correction, err := s.repo.GetCorrectionByID(ctx, correctionID)
+	if err != nil {
+		return nil, err
+	}
+	if correction == nil || correction.OrgID != orgID {
+		return nil, errors.New("correction not found")
+	}
+	if correction.Status != domain.CorrectionStatusPending {
+		return nil, errors.New("correction already reviewed")
+	}
+	return correction, nil
+}
+
+// correctedTimes returns the check-in and check-out times the record would
+// have after the correction, and checks that they are consistent.
+func correctedTimes(att *domain.Attendance, checkIn, checkOut *time.Time, now time.Time) (time.Time, *time.Time, error) {
+	if att.Status == domain.AttendanceStatusAbsent && (checkIn == nil || checkOut == nil) {
+		return time.Time{}, nil, errors.New("check-in and check-out times are required for a missed shift")
+	}
+
+	in := att.CheckInTime
+	if checkIn != nil {
+		in = *checkIn
+	}
+	out := att.CheckOutTime
+	if checkOut != nil {
+		out = checkOut
+	}
+
+	if err := checkSessionTimes(in, out, now); err != nil {
+		return time.Time{}, nil, err
+	}
+	return in, out, nil
+}
+
+func markReviewed(correction *domain.AttendanceCorrection, status, reviewerUserID, note string, at time.Time) {
+	correction.Status = status
+	correction.ReviewedBy = &reviewerUserID
+	correction.ReviewedAt = &at
+	correction.ReviewNote = note
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+)
+
+// idempotencyLockTimeout is how long a request may hold its key before a
+// retry can assume it was abandoned and take the key over.
+const idempotencyLockTimeout = time.Minute
+
+type IdempotencyService struct {
+	repo port.IdempotencyRepository
+	ttl  time.Duration
+}
+
+func NewIdempotencyService(repo port.IdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{repo: repo, ttl: ttl}
+}
+
+// Begin reserves the key for a new request. If the key has already been used
+// for the same request and that request has finished, the stored record is
+// returned for replay and nothing is reserved.
+func (s *IdempotencyService) Begin(ctx context.Context, userID, key, requestHash string) (*domain.IdempotencyKey, error) {
+	now := time.Now()
+	record := &domain.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+	err := s.repo.CreateIdempotencyKey(ctx, record, now.Add(-idempotencyLockTimeout))
+	if err == nil {
+		return nil, nil
+	}
+	var dupErr *domain.DuplicateError
+	if !errors.As(err, &dupErr) {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetIdempotencyKey(ctx, userID, key)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case existing != nil && existing.RequestHash != requestHash:
+		return nil, errors.New("idempotency key was used with a different request")
+	case existing == nil || existing.ResponseStatus == nil:
+		// A missing row was released by a failed request a moment ago.
+		return nil, errors.New("a request with this idempotency key is in progress")
+	}
+	return existing, nil
+}
+
+// Complete stores the response to replay for the reserved key.
+func (s *IdempotencyService) Complete(ctx context.Context, userID, key, requestHash string, status int, body []byte) error {
+	return s.repo.CompleteIdempotencyKey(ctx, &domain.IdempotencyKey{
+		UserID:         userID,
+		Key:            key,
+		RequestHash:    requestHash,
+		ResponseStatus: &status,
+		ResponseBody:   body,
+	})
+}
+
+// Release frees the reserved key so that the request can be retried, for
+// example after a server error.
+func (s *IdempotencyService) Release(ctx context.Context, userID, key string) error {
+	return s.repo.DeleteIdempotencyKey(ctx, userID, key)
+}
+
+// PurgeExpired deletes keys whose TTL has passed and reports how many.
+func (s *IdempotencyService) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	return s.repo.DeleteExpiredIdempotencyKeys(ctx, now)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+)
+
+// Sources of a kiosk entry, whose failures are counted separately.
+const (
+	kioskSourcePIN   = "PIN"
+	kioskSourceBadge = "BADGE"
+)
+
+type KioskService struct {
+	repo          port.KioskRepository
+	attSvc        *AttendanceService
+	pinSecret     []byte
+	maxAttempts   int
+	attemptWindow time.Duration
+	lockout       time.Duration
+}
+
+// NewKioskService creates a service that checks members in and out at kiosks
+// through attSvc. PINs are stored as HMACs keyed with pinSecret. After
+// maxAttempts unrecognized PINs, or badges, within attemptWindow, a kiosk
+// refuses entry for the lockout duration. Recognized entries do not clear
+// the count; failures only expire with the window.
+func NewKioskService(repo port.KioskRepository, attSvc *AttendanceService, pinSecret string, maxAttempts int, attemptWindow, lockout time.Duration) *KioskService {
+	return &KioskService{repo: repo, attSvc: attSvc, pinSecret: []byte(pinSecret), maxAttempts: maxAttempts, attemptWindow: attemptWindow, lockout: lockout}
+}
+
+// RegisterKiosk adds a kiosk to the organization and returns its device
+// token, which is not stored and cannot be shown again (Owner/Manager only).
+func (s *KioskService) RegisterKiosk(ctx context.Context, actorUserID, orgID string, kiosk *domain.Kiosk) (*domain.KioskRegistration, error) {
+	if err := requireManager(ctx, s.attSvc.orgRepo, orgID, actorUserID); err != nil {
+		return nil, err
+	}
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	kiosk.OrgID = orgID
+	kiosk.TokenHash = hashKioskToken(token)
+	if err := s.repo.CreateKiosk(ctx, kiosk); err != nil {
+		return nil, err
+	}
+	return &domain.KioskRegistration{Kiosk: kiosk, Token: token}, nil
+}
+
+// ListKiosks returns the organization's kiosks, revoked ones included
+// (Owner/Manager only).
+func (s *KioskService) ListKiosks(ctx context.Context, actorUserID, orgID string) ([]*domain.Kiosk, error) {
+	if err := requireManager(ctx, s.attSvc.orgRepo, orgID, actorUserID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListKiosks(ctx, orgID)
+}
+
+// RevokeKiosk stops the kiosk's device token from working (Owner/Manager
+// only). Attendance recorded at the kiosk keeps its reference.
+func (s *KioskService) RevokeKiosk(ctx context.Context, actorUserID, orgID, kioskID string) error {
+	if err := requireManager(ctx, s.attSvc.orgRepo, orgID, actorUserID); err != nil {
+		return err
+	}
+	kiosk, err := s.repo.GetKioskByID(ctx, kioskID)
+	if err != nil {
+		return err
+	}
+	if kiosk == nil || kiosk.OrgID != orgID {
+		return errors.New("kiosk not found")
+	}
+	return s.repo.RevokeKiosk(ctx, kioskID, time.Now())
+}
+
+// SetMemberCredentials replaces the PIN and badge a member uses at the
+// organization's kiosks (Owner/Manager only).
+func (s *KioskService) SetMemberCredentials(ctx context.Context, actorUserID, orgID, userID string, req *domain.KioskCredentialsRequest) error {
+	if err := requireManager(ctx, s.attSvc.orgRepo, orgID, actorUserID); err != nil {
+		return err
+	}
+	var pinHash, badgeID *string
+	if req.PIN != "" {
+		h := s.hashPIN(orgID, req.PIN)
+		pinHash = &h
+	}
+	if req.BadgeID != "" {
+		badgeID = &req.BadgeID
+	}
+	ok, err := s.repo.SetMemberCredentials(ctx, orgID, userID, pinHash, badgeID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("member not found")
+	}
+	return nil
+}
+
+// AuthenticateKiosk returns the active kiosk the device token belongs to.
+func (s *KioskService) AuthenticateKiosk(ctx context.Context, token string) (*domain.Kiosk, error) {
+	kiosk, err := s.repo.GetKioskByTokenHash(ctx, hashKioskToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if kiosk == nil || kiosk.RevokedAt != nil {
+		return nil, errors.New("invalid kiosk token")
+	}
+	return kiosk, nil
+}
+
+// CheckIn opens a session for the member identified by PIN or badge. The
+// kiosk stands in for the member's location.
+func (s *KioskService) CheckIn(ctx context.Context, kiosk *domain.Kiosk, req *domain.KioskPunchRequest, clientIP string) (*domain.Attendance, error) {
+	userID, err := s.identifyMember(ctx, kiosk, req)
+	if err != nil {
+		return nil, err
+	}
+	att := &domain.Attendance{
+		ProofType: domain.ProofTypeKiosk,
+		KioskID:   &kiosk.ID,
+		ClientIP:  clientIP,
+		Note:      req.Note,
+	}
+	return s.attSvc.CheckIn(ctx, userID, kiosk.OrgID, att, "")
+}
+
+// CheckOut closes the open session of the member identified by PIN or badge
+// in the kiosk's organization.
+func (s *KioskService) CheckOut(ctx context.Context, kiosk *domain.Kiosk, req *domain.KioskPunchRequest) (*domain.Attendance, error) {
+	userID, err := s.identifyMember(ctx, kiosk, req)
+	if err != nil {
+		return nil, err
+	}
+	checkOut := &domain.CheckOutRequest{OrganizationID: kiosk.OrgID, Note: req.Note}
+	return s.attSvc.checkOutAt(ctx, userID, checkOut, checkOutOptions{at: time.Now(), kioskID: &kiosk.ID})
+}
+
+// identifyMember resolves the PIN, or the badge if no PIN is given, to a
+// member of the kiosk's organization. Unrecognized entries count towards the
+// kiosk's lockout for their source.
+func (s *KioskService) identifyMember(ctx context.Context, kiosk *domain.Kiosk, req *domain.KioskPunchRequest) (string, error) {
+	now := time.Now()
+	if kiosk.LockedUntil != nil && now.Before(*kiosk.LockedUntil) {
+		return "", errors.New("too many failed attempts, try again later")
+	}
+
+	var userID, source string
+	var err error
+	if req.PIN != "" {
+		source = kioskSourcePIN
+		userID, err = s.repo.FindMemberByPIN(ctx, kiosk.OrgID, s.hashPIN(kiosk.OrgID, req.PIN))
+	} else {
+		source = kioskSourceBadge
+		userID, err = s.repo.FindMemberByBadge(ctx, kiosk.OrgID, req.BadgeID)
+	}
+	if err != nil {
+		return "", err
+	}
+	if userID == "" {
+		failures, err := s.repo.RecordFailedAttempt(ctx, kiosk.ID, source, now, now.Add(-s.attemptWindow))
+		if err != nil {
+			return "", err
+		}
+		if failures >= s.maxAttempts {
+			if err := s.repo.LockKiosk(ctx, kiosk.ID, now.Add(s.lockout)); err != nil {
+				return "", err
+			}
+		}
+		return "", errors.New("unknown pin or badge")
+	}
+	return userID, nil
+}
+
+// hashPIN keys the PIN to the organization, so equal PINs in different
+// organizations hash differently.
+func (s *KioskService) hashPIN(orgID, pin string) string {
+	mac := hmac.New(sha256.New, s.pinSecret)
+	mac.Write([]byte(orgID + ":" + pin))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func hashKioskToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"net/netip"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+)
+
+// networkPolicy is the set of networks check-ins must come from.
+type networkPolicy struct {
+	prefixes []netip.Prefix
+	mode     string // WARN or REJECT
+}
+
+// orgNetworkPolicy returns the organization's network allowlist, or nil when
+// none is enforced.
+func orgNetworkPolicy(org *domain.Organization) *networkPolicy {
+	if org.NetworkMode == "" || org.NetworkMode == domain.GeofenceModeOff || len(org.AllowedNetworks) == 0 {
+		return nil
+	}
+	policy := &networkPolicy{mode: org.NetworkMode}
+	for _, cidr := range org.AllowedNetworks {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue // Validated when the organization is saved
+		}
+		policy.prefixes = append(policy.prefixes, prefix.Masked())
+	}
+	return policy
+}
+
+// allows reports whether the client address is in one of the networks. An
+// unknown address is not allowed.
+func (p *networkPolicy) allows(clientIP string) bool {
+	addr, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
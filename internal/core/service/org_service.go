@@ -19,6 +19,8 @@ func NewOrgService(repo port.OrgRepository, userRepo port.UserRepository, txMgr
 }
 
 func (s *OrgService) CreateOrganization(ctx context.Context, userID string, req *domain.Organization) (*domain.Organization, error) {
+	setLocationPolicyDefaults(req)
+
 	// Transactional: Create Org and Add User as Owner
 	err := s.txMgr.RunInTx(ctx, func(ctx context.Context) error {
 		if err := s.repo.CreateOrganization(ctx, req); err != nil {
@@ -46,6 +48,9 @@ func (s *OrgService) GetOrganization(ctx context.Context, id string) (*domain.Or
 	return s.repo.GetOrganizationByID(ctx, id)
 }
 
+// UpdateOrganization replaces the organization's details. Location policy
+// fields the request leaves empty keep their current values, so an update
+// that omits them cannot turn enforcement off.
 func (s *OrgService) UpdateOrganization(ctx context.Context, userID string, req *domain.Organization) error {
 	// Check if user is OWNER
 	member, err := s.repo.GetMember(ctx, req.ID, userID)
@@ -56,9 +61,32 @@ func (s *OrgService) UpdateOrganization(ctx context.Context, userID string, req
 		return errors.New("only owner can update organization")
 	}
 
-	// Fetch existing org to preserve fields if needed, or just update
-	// For now assuming req contains all fields or we merge.
-	// The repository update updates specific fields.
+	existing, err := s.repo.GetOrganizationByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if req.GeofenceMode == "" {
+		req.GeofenceMode = existing.GeofenceMode
+	}
+	if req.GeofenceRadiusMeters == 0 {
+		req.GeofenceRadiusMeters = existing.GeofenceRadiusMeters
+	}
+	if req.NetworkMode == "" {
+		req.NetworkMode = existing.NetworkMode
+	}
+	if req.AllowedNetworks == nil {
+		req.AllowedNetworks = existing.AllowedNetworks
+	}
+	if req.LocationRule == "" {
+		req.LocationRule = existing.LocationRule
+	}
+	setLocationPolicyDefaults(req)
+
+	// The request alone passed validation, but an explicitly emptied list can
+	// leave the kept network mode with nothing to enforce
+	if req.NetworkMode != domain.GeofenceModeOff && len(req.AllowedNetworks) == 0 {
+		return errors.New("allowed_networks is required while network_mode is " + req.NetworkMode)
+	}
 
 	return s.repo.UpdateOrganization(ctx, req)
 }
@@ -80,14 +108,48 @@ func (s *OrgService) ListOrganizations(ctx context.Context, userID string) ([]*d
 	return s.repo.ListOrganizations(ctx, userID)
 }
 
+// CreateShift adds a shift. A shift at a site without its own timezone uses
+// the site's. A split shift starts with its first segment and ends with its
+// last.
 func (s *OrgService) CreateShift(ctx context.Context, shift *domain.Shift) (*domain.Shift, error) {
+	if len(shift.Segments) > 0 {
+		if err := checkSegments(shift); err != nil {
+			return nil, err
+		}
+		shift.StartTime = shift.Segments[0].StartTime
+		shift.EndTime = shift.Segments[len(shift.Segments)-1].EndTime
+	}
+	if shift.SiteID != nil {
+		site, err := orgSite(ctx, s.repo, shift.OrgID, *shift.SiteID)
+		if err != nil {
+			return nil, err
+		}
+		if shift.Timezone == "" {
+			shift.Timezone = site.Timezone
+		}
+	}
 	if err := s.repo.CreateShift(ctx, shift); err != nil {
 		return nil, err
 	}
 	return shift, nil
 }
 
+// CreateGroup adds a group working to a shift, a rotation or, until one is
+// set, neither.
 func (s *OrgService) CreateGroup(ctx context.Context, group *domain.Group) (*domain.Group, error) {
+	if group.ShiftID != nil && group.RotationID != nil {
+		return nil, errors.New("only one of shift_id and rotation_id can be set")
+	}
+	if group.RotationID != nil {
+		if _, err := s.orgRotation(ctx, group.OrgID, *group.RotationID); err != nil {
+			return nil, err
+		}
+	}
+	if group.SiteID != nil {
+		if _, err := orgSite(ctx, s.repo, group.OrgID, *group.SiteID); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.repo.CreateGroup(ctx, group); err != nil {
 		return nil, err
 	}
@@ -184,3 +246,353 @@ func (s *OrgService) RemoveEmployee(ctx context.Context, requesterUserID, orgID,
 
 	return s.repo.RemoveOrganizationMember(ctx, orgID, targetUserID)
 }
+
+// CreateSite adds a work site to the organization (Owner/Manager only).
+func (s *OrgService) CreateSite(ctx context.Context, requesterUserID, orgID string, site *domain.Site) (*domain.Site, error) {
+	if err := requireManager(ctx, s.repo, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	site.OrgID = orgID
+	if err := s.repo.CreateSite(ctx, site); err != nil {
+		return nil, err
+	}
+	return site, nil
+}
+
+// ListSites returns the organization's sites by name to any member.
+func (s *OrgService) ListSites(ctx context.Context, requesterUserID, orgID string) ([]*domain.Site, error) {
+	if _, err := s.repo.GetMember(ctx, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	sites, err := s.repo.ListSites(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if sites == nil {
+		sites = []*domain.Site{}
+	}
+	return sites, nil
+}
+
+// GetSite returns one of the organization's sites to any member.
+func (s *OrgService) GetSite(ctx context.Context, requesterUserID, orgID, siteID string) (*domain.Site, error) {
+	if _, err := s.repo.GetMember(ctx, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	return orgSite(ctx, s.repo, orgID, siteID)
+}
+
+// UpdateSite replaces the site's details (Owner/Manager only). Attendance
+// already recorded at the site keeps its distances.
+func (s *OrgService) UpdateSite(ctx context.Context, requesterUserID, orgID string, site *domain.Site) (*domain.Site, error) {
+	if err := requireManager(ctx, s.repo, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	site.OrgID = orgID
+	updated, err := s.repo.UpdateSite(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.New("site not found")
+	}
+	return site, nil
+}
+
+// DeleteSite removes the site (Owner/Manager only). Groups, shifts, tasks and
+// attendance records at the site are left without one.
+func (s *OrgService) DeleteSite(ctx context.Context, requesterUserID, orgID, siteID string) error {
+	if err := requireManager(ctx, s.repo, orgID, requesterUserID); err != nil {
+		return err
+	}
+	deleted, err := s.repo.DeleteSite(ctx, orgID, siteID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("site not found")
+	}
+	return nil
+}
+
+// orgSite returns the site if it belongs to the organization.
+func orgSite(ctx context.Context, repo port.OrgRepository, orgID, siteID string) (*domain.Site, error) {
+	site, err := repo.GetSiteByID(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	if site == nil || site.OrgID != orgID {
+		return nil, errors.New("site not found")
+	}
+	return site, nil
+}
+
+// CreateScheduleAssignment puts a member on a shift or a rotation from the assignment's
+// effective date (Owner/Manager only). An open-ended assignment already in
+// effect then is ended the day before; any other overlap is refused. Past
+// dates cannot be assigned, so attendance keeps the schedule it was made
+// under.
+func (s *OrgService) CreateScheduleAssignment(ctx context.Context, requesterUserID, orgID, userID string, assignment *domain.ScheduleAssignment) (*domain.ScheduleAssignment, error) {
+	if err := requireManager(ctx, s.repo, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	if (assignment.ShiftID == nil) == (assignment.RotationID == nil) {
+		return nil, errors.New("exactly one of shift_id and rotation_id is required")
+	}
+	assignment.OrgID = orgID
+	assignment.UserID = userID
+	timezone, err := s.assignmentTimezone(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.EffectiveFrom < todayIn(timezone) {
+		return nil, errors.New("effective_from cannot be in the past")
+	}
+	if assignment.EffectiveTo != nil && *assignment.EffectiveTo < assignment.EffectiveFrom {
+		return nil, errors.New("effective_to cannot be before effective_from")
+	}
+
+	err = s.txMgr.RunInTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.ListScheduleAssignments(ctx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		for _, other := range existing {
+			if other.EffectiveTo == nil && other.EffectiveFrom < assignment.EffectiveFrom {
+				end := previousDate(assignment.EffectiveFrom)
+				if err := s.repo.SetScheduleAssignmentEnd(ctx, other.ID, &end); err != nil {
+					return err
+				}
+				continue
+			}
+			if assignmentsOverlap(other, assignment.EffectiveFrom, assignment.EffectiveTo) {
+				return errors.New("schedule assignment overlaps another")
+			}
+		}
+		return s.repo.CreateScheduleAssignment(ctx, assignment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// ListScheduleAssignments returns the member's schedule assignments, past
+// ones included, to managers and to the member themself.
+func (s *OrgService) ListScheduleAssignments(ctx context.Context, requesterUserID, orgID, userID string) ([]*domain.ScheduleAssignment, error) {
+	if requesterUserID != userID {
+		if err := requireManager(ctx, s.repo, orgID, requesterUserID); err != nil {
+			return nil, err
+		}
+	} else if _, err := s.repo.GetMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	assignments, err := s.repo.ListScheduleAssignments(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if assignments == nil {
+		assignments = []*domain.ScheduleAssignment{}
+	}
+	return assignments, nil
+}
+
+// EndScheduleAssignment sets the assignment's last day (Owner/Manager only),
+// after which the member is back on their group's schedule. It cannot end before
+// today.
+func (s *OrgService) EndScheduleAssignment(ctx context.Context, requesterUserID, orgID, userID, assignmentID, effectiveTo string) (*domain.ScheduleAssignment, error) {
+	if err := requireManager(ctx, s.repo, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	var assignment *domain.ScheduleAssignment
+	err := s.txMgr.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		assignment, err = s.memberAssignment(ctx, orgID, userID, assignmentID)
+		if err != nil {
+			return err
+		}
+		timezone, err := s.assignmentTimezone(ctx, assignment)
+		if err != nil {
+			return err
+		}
+		if effectiveTo < todayIn(timezone) {
+			return errors.New("cannot end a schedule assignment before today")
+		}
+		if effectiveTo < assignment.EffectiveFrom {
+			return errors.New("effective_to cannot be before effective_from")
+		}
+
+		existing, err := s.repo.ListScheduleAssignments(ctx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		for _, other := range existing {
+			if other.ID != assignment.ID && assignmentsOverlap(other, assignment.EffectiveFrom, &effectiveTo) {
+				return errors.New("schedule assignment overlaps another")
+			}
+		}
+		assignment.EffectiveTo = &effectiveTo
+		return s.repo.SetScheduleAssignmentEnd(ctx, assignment.ID, assignment.EffectiveTo)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// DeleteScheduleAssignment removes an assignment that has not yet taken
+// effect (Owner/Manager only). Assignments already in effect are ended
+// instead, keeping the history.
+func (s *OrgService) DeleteScheduleAssignment(ctx context.Context, requesterUserID, orgID, userID, assignmentID string) error {
+	if err := requireManager(ctx, s.repo, orgID, requesterUserID); err != nil {
+		return err
+	}
+	assignment, err := s.memberAssignment(ctx, orgID, userID, assignmentID)
+	if err != nil {
+		return err
+	}
+	timezone, err := s.assignmentTimezone(ctx, assignment)
+	if err != nil {
+		return err
+	}
+	if assignment.EffectiveFrom <= todayIn(timezone) {
+		return errors.New("schedule assignment already in effect")
+	}
+	return s.repo.DeleteScheduleAssignment(ctx, assignment.ID)
+}
+
+// memberAssignment returns the assignment if it belongs to the member.
+func (s *OrgService) memberAssignment(ctx context.Context, orgID, userID, assignmentID string) (*domain.ScheduleAssignment, error) {
+	assignment, err := s.repo.GetScheduleAssignmentByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil || assignment.OrgID != orgID || assignment.UserID != userID {
+		return nil, errors.New("schedule assignment not found")
+	}
+	return assignment, nil
+}
+
+// assignmentTimezone returns the timezone of the assignment's shift or
+// rotation, which its dates are in.
+func (s *OrgService) assignmentTimezone(ctx context.Context, assignment *domain.ScheduleAssignment) (string, error) {
+	if assignment.RotationID != nil {
+		rotation, err := s.orgRotation(ctx, assignment.OrgID, *assignment.RotationID)
+		if err != nil {
+			return "", err
+		}
+		return rotation.Timezone, nil
+	}
+	shift, err := s.orgShift(ctx, assignment.OrgID, *assignment.ShiftID)
+	if err != nil {
+		return "", err
+	}
+	return shift.Timezone, nil
+}
+
+// CreateRotation adds a rotation (Owner/Manager only). Its shifts must belong
+// to the organization and share a timezone, which the rotation takes.
+func (s *OrgService) CreateRotation(ctx context.Context, requesterUserID, orgID string, rotation *domain.Rotation) (*domain.Rotation, error) {
+	if err := requireManager(ctx, s.repo, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	rotation.OrgID = orgID
+	rotation.Timezone = ""
+	checked := make(map[string]bool)
+	for _, shiftID := range rotation.Days {
+		if shiftID == nil || checked[*shiftID] {
+			continue
+		}
+		checked[*shiftID] = true
+		shift, err := s.orgShift(ctx, orgID, *shiftID)
+		if err != nil {
+			return nil, err
+		}
+		if rotation.Timezone != "" && shift.Timezone != rotation.Timezone {
+			return nil, errors.New("rotation shifts must share a timezone")
+		}
+		rotation.Timezone = shift.Timezone
+	}
+	if rotation.Timezone == "" {
+		return nil, errors.New("rotation needs at least one shift day")
+	}
+	if err := s.repo.CreateRotation(ctx, rotation); err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// ListRotations returns the organization's rotations by name to any member.
+func (s *OrgService) ListRotations(ctx context.Context, requesterUserID, orgID string) ([]*domain.Rotation, error) {
+	if _, err := s.repo.GetMember(ctx, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	rotations, err := s.repo.ListRotations(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if rotations == nil {
+		rotations = []*domain.Rotation{}
+	}
+	return rotations, nil
+}
+
+// GetRotation returns one of the organization's rotations to any member.
+func (s *OrgService) GetRotation(ctx context.Context, requesterUserID, orgID, rotationID string) (*domain.Rotation, error) {
+	if _, err := s.repo.GetMember(ctx, orgID, requesterUserID); err != nil {
+		return nil, err
+	}
+	return s.orgRotation(ctx, orgID, rotationID)
+}
+
+// orgRotation returns the rotation if it belongs to the organization.
+func (s *OrgService) orgRotation(ctx context.Context, orgID, rotationID string) (*domain.Rotation, error) {
+	rotation, err := s.repo.GetRotationByID(ctx, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	if rotation == nil || rotation.OrgID != orgID {
+		return nil, errors.New("rotation not found")
+	}
+	return rotation, nil
+}
+
+// orgShift returns the shift if it belongs to the organization.
+func (s *OrgService) orgShift(ctx context.Context, orgID, shiftID string) (*domain.Shift, error) {
+	shift, err := s.repo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	if shift == nil || shift.OrgID != orgID {
+		return nil, errors.New("shift not found")
+	}
+	return shift, nil
+}
+
+// requireManager checks that the user is an owner or manager of the
+// organization.
+func requireManager(ctx context.Context, repo port.OrgRepository, orgID, userID string) error {
+	member, err := repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role != "OWNER" && member.Role != "MANAGER" {
+		return errors.New("unauthorized")
+	}
+	return nil
+}
+
+func setLocationPolicyDefaults(org *domain.Organization) {
+	if org.GeofenceMode == "" {
+		org.GeofenceMode = domain.GeofenceModeOff
+	}
+	if org.NetworkMode == "" {
+		org.NetworkMode = domain.GeofenceModeOff
+	}
+	if org.LocationRule == "" {
+		org.LocationRule = domain.LocationRuleAll
+	}
+}
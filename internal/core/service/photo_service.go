@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+)
+
+// photoTypes maps the accepted photo content types, as sniffed from the
+// file itself, to the extension the file is stored with.
+var photoTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+var errPhotoTooLarge = errors.New("photo too large")
+
+type PhotoService struct {
+	repo    port.AttendanceRepository
+	attSvc  *AttendanceService
+	storage port.FileStorage
+	maxSize int64
+}
+
+// NewPhotoService creates a service that keeps check-in photos of up to
+// maxSize bytes in storage.
+func NewPhotoService(repo port.AttendanceRepository, attSvc *AttendanceService, storage port.FileStorage, maxSize int64) *PhotoService {
+	return &PhotoService{repo: repo, attSvc: attSvc, storage: storage, maxSize: maxSize}
+}
+
+// MaxSize is the largest photo accepted, in bytes.
+func (s *PhotoService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// UploadPhoto attaches a photo to one of the user's own attendance records.
+// The content type is sniffed from the data rather than trusted from the
+// client. A record takes a single photo, which cannot be replaced.
+func (s *PhotoService) UploadPhoto(ctx context.Context, userID, attendanceID string, r io.Reader) (*domain.Attendance, error) {
+	att, err := s.repo.GetAttendanceByID(ctx, attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if att == nil || att.UserID != userID {
+		return nil, errors.New("attendance not found")
+	}
+	if att.PhotoKey != "" {
+		return nil, errors.New("attendance already has a photo")
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("photo is empty")
+		}
+		return nil, err
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	ext, ok := photoTypes[contentType]
+	if !ok {
+		return nil, errors.New("unsupported photo type")
+	}
+
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return nil, err
+	}
+	key := "attendance/" + att.OrgID + "/" + att.ID + "/" + hex.EncodeToString(name) + ext
+
+	body := &sizeLimitedReader{r: io.MultiReader(bytes.NewReader(head), r), remaining: s.maxSize}
+	if err := s.storage.Save(ctx, key, body); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	att.PhotoKey = key
+	att.PhotoContentType = contentType
+	att.PhotoSizeBytes = s.maxSize - body.remaining
+	att.PhotoUploadedAt = &now
+	ok, err = s.repo.SetAttendancePhoto(ctx, att)
+	if err != nil || !ok {
+		// Do not leave an orphaned file behind
+		_ = s.storage.Delete(context.WithoutCancel(ctx), key)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New("attendance already has a photo")
+	}
+	return att, nil
+}
+
+// Photo opens the photo attached to an attendance record in the
+// organization (Owner/Manager only). The caller closes the reader.
+func (s *PhotoService) Photo(ctx context.Context, actorUserID, orgID, attendanceID string) (io.ReadCloser, *domain.Attendance, error) {
+	if err := requireManager(ctx, s.attSvc.orgRepo, orgID, actorUserID); err != nil {
+		return nil, nil, err
+	}
+	att, err := s.repo.GetAttendanceByID(ctx, attendanceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if att == nil || att.OrgID != orgID {
+		return nil, nil, errors.New("attendance not found")
+	}
+	if att.PhotoKey == "" {
+		return nil, nil, errors.New("photo not found")
+	}
+	rc, err := s.storage.Open(ctx, att.PhotoKey)
+	if errors.Is(err, port.ErrFileNotFound) {
+		return nil, nil, errors.New("photo not found")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return rc, att, nil
+}
+
+// sizeLimitedReader fails with errPhotoTooLarge once more than remaining
+// bytes have been read.
+type sizeLimitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		l.remaining = 0
+		return 0, errPhotoTooLarge
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/syst3mctl/check-in-api/internal/core/domain"
 	"github.com/syst3mctl/check-in-api/internal/core/port"
@@ -15,6 +17,37 @@ func NewReportService(repo port.ReportRepository) *ReportService {
 	return &ReportService{repo: repo}
 }
 
-func (s *ReportService) GetGroupPerformance(ctx context.Context, groupID string) (*domain.GroupPerformanceReport, error) {
-	return s.repo.GetGroupPerformance(ctx, groupID)
+// GetGroupPerformance reports on the group's shift instances dated within the
+// inclusive [from, to] range.
+func (s *ReportService) GetGroupPerformance(ctx context.Context, groupID string, from, to time.Time) (*domain.GroupPerformanceReport, error) {
+	report, err := s.repo.GetGroupPerformance(ctx, groupID, from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.repo.GetGroupSchedule(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		report.AttendanceRate = "N/A"
+		return report, nil
+	}
+
+	resolver, err := newScheduleResolver(schedule)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.CountGroupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.ExpectedShifts = members * len(resolver.occurrencesBetween(from, to))
+	if report.ExpectedShifts == 0 {
+		report.AttendanceRate = "N/A"
+		return report, nil
+	}
+	report.AttendanceRate = fmt.Sprintf("%.1f%%", float64(report.AttendedShifts)*100/float64(report.ExpectedShifts))
+	return report, nil
 }
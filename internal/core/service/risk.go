@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/pkg/geo"
+)
+
+// riskWeights are the points each location risk check adds to a record's
+// risk score, which is capped at 100.
+var riskWeights = map[string]int{
+	domain.RiskMockLocation:      60,
+	domain.RiskPoorAccuracy:      20,
+	domain.RiskReusedCoordinates: 40,
+	domain.RiskImpossibleTravel:  50,
+}
+
+// minTravelDistanceMeters ignores short moves between punches, which GPS
+// noise alone can make look implausibly fast.
+const minTravelDistanceMeters = 1000
+
+// RiskPolicy configures the location risk checks run on GPS check-ins. A
+// zero field disables its check; a reported mock location is always scored.
+type RiskPolicy struct {
+	MaxAccuracyMeters     float64       // Reported accuracy worse than this is POOR_ACCURACY
+	MaxTravelSpeedKmh     float64       // Faster travel from the previous punch is IMPOSSIBLE_TRAVEL
+	CoordinateReuseWindow time.Duration // How far back another member's identical coordinates count
+}
+
+// assessRisk scores how likely the check-in location is to be spoofed. The
+// score is informational: the check-in is not refused or flagged for it.
+func (s *AttendanceService) assessRisk(ctx context.Context, userID string, att *domain.Attendance) error {
+	var reasons []string
+	if att.MockLocation {
+		reasons = append(reasons, domain.RiskMockLocation)
+	}
+	if s.risk.MaxAccuracyMeters > 0 && att.LocationAccuracy != nil && *att.LocationAccuracy > s.risk.MaxAccuracyMeters {
+		reasons = append(reasons, domain.RiskPoorAccuracy)
+	}
+	if s.risk.CoordinateReuseWindow > 0 {
+		used, err := s.repo.CoordinatesUsedByOthers(ctx, userID, att.LocationLat, att.LocationLong, att.CheckInTime.Add(-s.risk.CoordinateReuseWindow))
+		if err != nil {
+			return err
+		}
+		if used {
+			reasons = append(reasons, domain.RiskReusedCoordinates)
+		}
+	}
+	if s.risk.MaxTravelSpeedKmh > 0 {
+		last, err := s.repo.GetLastPunchLocation(ctx, userID, att.CheckInTime)
+		if err != nil {
+			return err
+		}
+		if last != nil && impossibleTravel(last, att, s.risk.MaxTravelSpeedKmh) {
+			reasons = append(reasons, domain.RiskImpossibleTravel)
+		}
+	}
+
+	score := 0
+	for _, reason := range reasons {
+		score += riskWeights[reason]
+	}
+	att.RiskScore = min(score, 100)
+	att.RiskReasons = reasons
+	return nil
+}
+
+// impossibleTravel reports whether getting from the previous punch to the
+// check-in location would take more than maxSpeedKmh.
+func impossibleTravel(last *domain.PunchLocation, att *domain.Attendance, maxSpeedKmh float64) bool {
+	distance := geo.Distance(last.Latitude, last.Longitude, att.LocationLat, att.LocationLong)
+	if distance <= minTravelDistanceMeters {
+		return false
+	}
+	hours := att.CheckInTime.Sub(last.Time).Hours()
+	return hours <= 0 || distance/1000/hours > maxSpeedKmh
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+var weekdayCodes = map[time.Weekday]string{
+	time.Monday:    "MON",
+	time.Tuesday:   "TUE",
+	time.Wednesday: "WED",
+	time.Thursday:  "THU",
+	time.Friday:    "FRI",
+	time.Saturday:  "SAT",
+	time.Sunday:    "SUN",
+}
+
+// parseClock parses a shift wall-clock time. Both HH:MM and the HH:MM:SS form
+// returned by Postgres TIME columns are accepted.
+func parseClock(value string) (hour, minute int, err error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		t, err = time.Parse("15:04:05", value)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid shift time %q", value)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// todayIn returns today's date in the IANA timezone.
+func todayIn(timezone string) string {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return time.Now().In(loc).Format(dateLayout)
+}
+
+// previousDate returns the day before a YYYY-MM-DD date.
+func previousDate(date string) string {
+	d, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return date
+	}
+	return d.AddDate(0, 0, -1).Format(dateLayout)
+}
+
+// assignmentsOverlap reports whether the assignment shares a date with the
+// range from through to, which is open-ended when to is nil.
+func assignmentsOverlap(a *domain.ScheduleAssignment, from string, to *string) bool {
+	return (to == nil || a.EffectiveFrom <= *to) && (a.EffectiveTo == nil || *a.EffectiveTo >= from)
+}
+
+// shiftTimes holds a shift's segments in minutes after midnight of the day
+// it starts on, running past 24*60 when they end on the next day. A shift
+// that is not split has a single segment.
+type shiftTimes struct {
+	shift    *domain.Shift
+	segments []segmentTimes
+}
+
+type segmentTimes struct {
+	startMinute int
+	endMinute   int
+}
+
+func newShiftTimes(shift *domain.Shift) (*shiftTimes, error) {
+	clocks := shift.Segments
+	if len(clocks) == 0 {
+		clocks = []domain.ShiftSegment{{StartTime: shift.StartTime, EndTime: shift.EndTime}}
+	}
+
+	times := &shiftTimes{shift: shift}
+	previousEnd := 0
+	for _, clock := range clocks {
+		startHour, startMinute, err := parseClock(clock.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		endHour, endMinute, err := parseClock(clock.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		// Each segment follows the previous one, and ends after it starts
+		start := startHour*60 + startMinute
+		for start < previousEnd {
+			start += 24 * 60
+		}
+		end := endHour*60 + endMinute
+		for end <= start {
+			end += 24 * 60
+		}
+		times.segments = append(times.segments, segmentTimes{startMinute: start, endMinute: end})
+		previousEnd = end
+	}
+	return times, nil
+}
+
+// checkSegments checks that a split shift's segments fit within a day, so
+// consecutive instances cannot overlap.
+func checkSegments(shift *domain.Shift) error {
+	times, err := newShiftTimes(shift)
+	if err != nil {
+		return err
+	}
+	first, last := times.segments[0], times.segments[len(times.segments)-1]
+	if last.endMinute-first.startMinute >= 24*60 {
+		return errors.New("shift segments must span less than a day")
+	}
+	return nil
+}
+
+// shiftResolver maps instants to concrete instances of a schedule in its
+// IANA timezone: a single shift on its working days, or a rotation giving
+// each date one of its shifts or a day off. Wall-clock times are resolved per
+// calendar day, so lateness stays correct across DST transitions, and an end
+// time at or before the start time means the shift ends on the following day.
+type shiftResolver struct {
+	loc     *time.Location
+	shiftOn func(date time.Time) *shiftTimes // nil on a day off
+}
+
+func newShiftResolver(shift *domain.Shift) (*shiftResolver, error) {
+	loc, err := time.LoadLocation(shift.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shift timezone %q", shift.Timezone)
+	}
+	times, err := newShiftTimes(shift)
+	if err != nil {
+		return nil, err
+	}
+	return &shiftResolver{
+		loc: loc,
+		shiftOn: func(date time.Time) *shiftTimes {
+			if !slices.Contains(shift.WorkingDays, weekdayCodes[date.Weekday()]) {
+				return nil
+			}
+			return times
+		},
+	}, nil
+}
+
+// newRotationResolver resolves a rotation, whose Shifts must be loaded.
+func newRotationResolver(rotation *domain.Rotation) (*shiftResolver, error) {
+	loc, err := time.LoadLocation(rotation.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rotation timezone %q", rotation.Timezone)
+	}
+	anchor, err := time.ParseInLocation(dateLayout, rotation.AnchorDate, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rotation anchor date %q", rotation.AnchorDate)
+	}
+	days := make([]*shiftTimes, len(rotation.Days))
+	for i, shiftID := range rotation.Days {
+		if shiftID == nil {
+			continue
+		}
+		shift, ok := rotation.Shifts[*shiftID]
+		if !ok {
+			return nil, fmt.Errorf("rotation shift %s not loaded", *shiftID)
+		}
+		if days[i], err = newShiftTimes(shift); err != nil {
+			return nil, err
+		}
+	}
+	return &shiftResolver{
+		loc: loc,
+		shiftOn: func(date time.Time) *shiftTimes {
+			// Count calendar days, not hours, so DST changes do not shift the cycle
+			elapsed := civilDays(date) - civilDays(anchor)
+			n := int64(len(days))
+			return days[((elapsed%n)+n)%n]
+		},
+	}, nil
+}
+
+// newScheduleResolver resolves whichever of a shift or a rotation the
+// schedule has.
+func newScheduleResolver(schedule *domain.Schedule) (*shiftResolver, error) {
+	if schedule.Rotation != nil {
+		return newRotationResolver(schedule.Rotation)
+	}
+	return newShiftResolver(schedule.Shift)
+}
+
+// civilDays numbers the calendar date of t, whatever its zone.
+func civilDays(t time.Time) int64 {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// occurrencesOn returns the shift instance starting on the given local date,
+// one per segment of a split shift, or nil when the date is a day off.
+func (r *shiftResolver) occurrencesOn(year int, month time.Month, day int) []*domain.ShiftOccurrence {
+	claims := r.claimsOn(year, month, day)
+	if claims == nil {
+		return nil
+	}
+	occurrences := make([]*domain.ShiftOccurrence, len(claims))
+	for i, c := range claims {
+		occurrences[i] = c.occ
+	}
+	return occurrences
+}
+
+// claim is a shift instance and the time it claims before its neighbours
+// are considered.
+type claim struct {
+	occ      *domain.ShiftOccurrence
+	from, to time.Time
+}
+
+// claimsOn returns the instances starting on the given local date with the
+// time each claims. The gap between segments of a split shift belongs to the
+// next segment, since a segment that has ended can no longer be checked in
+// to. Otherwise an instance claims half the gap to where its shift falls on
+// the neighbouring dates, so the instances of consecutive working days meet
+// even when a DST change lengthens or shortens the night between them.
+func (r *shiftResolver) claimsOn(year int, month time.Month, day int) []claim {
+	date := time.Date(year, month, day, 0, 0, 0, 0, r.loc)
+	times := r.shiftOn(date)
+	if times == nil {
+		return nil
+	}
+
+	// time.Date normalizes minutes past midnight, so wall-clock times hold across DST
+	first, last := times.segments[0], times.segments[len(times.segments)-1]
+	previousEnd := time.Date(year, month, day-1, 0, last.endMinute, 0, 0, r.loc)
+	nextStart := time.Date(year, month, day+1, 0, first.startMinute, 0, 0, r.loc)
+
+	claims := make([]claim, 0, len(times.segments))
+	for i, segment := range times.segments {
+		occ := &domain.ShiftOccurrence{
+			Date:  date.Format(dateLayout),
+			Start: time.Date(year, month, day, 0, segment.startMinute, 0, 0, r.loc),
+			End:   time.Date(year, month, day, 0, segment.endMinute, 0, 0, r.loc),
+			Shift: times.shift,
+		}
+		if len(times.segments) > 1 {
+			occ.Segment = i + 1
+		}
+
+		c := claim{occ: occ, from: occ.Start.Add(-occ.Start.Sub(previousEnd) / 2), to: occ.End}
+		if i > 0 {
+			c.from = claims[i-1].to
+		}
+		if i == len(times.segments)-1 {
+			c.to = occ.End.Add(nextStart.Sub(occ.End) / 2)
+		}
+		claims = append(claims, c)
+	}
+	return claims
+}
+
+// resolve returns the shift instance the instant belongs to, or nil when it
+// falls on a day off. Each instance claims the time from halfway through the
+// gap before it to halfway through the gap after it, so early arrivals, late
+// check-ins and overnight sessions are attributed to the nearest instance.
+// Within a split shift the gap between segments belongs to the next segment.
+func (r *shiftResolver) resolve(at time.Time) *domain.ShiftOccurrence {
+	local := at.In(r.loc)
+
+	var claims []claim
+	for offset := -1; offset <= 1; offset++ {
+		claims = append(claims, r.claimsOn(local.Year(), local.Month(), local.Day()+offset)...)
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		return claims[i].occ.Start.Before(claims[j].occ.Start)
+	})
+
+	for i, c := range claims {
+		// A rotation can put a different shift on the neighbouring date, so
+		// overlapping claims are split halfway between the instances
+		from, to := c.from, c.to
+		if i > 0 {
+			if prev := claims[i-1].occ; prev.Date != c.occ.Date {
+				if mid := prev.End.Add(c.occ.Start.Sub(prev.End) / 2); mid.After(from) {
+					from = mid
+				}
+			}
+		}
+		if i < len(claims)-1 {
+			if next := claims[i+1].occ; next.Date != c.occ.Date {
+				if mid := c.occ.End.Add(next.Start.Sub(c.occ.End) / 2); mid.Before(to) {
+					to = mid
+				}
+			}
+		}
+		if !at.Before(from) && at.Before(to) {
+			return c.occ
+		}
+	}
+	return nil
+}
+
+// occurrencesBetween returns every shift instance, or segment of a split
+// shift, whose local date lies within the inclusive date range. Only the
+// calendar dates of from and to are used, so callers pass them in the zone
+// they mean the dates in.
+func (r *shiftResolver) occurrencesBetween(from, to time.Time) []*domain.ShiftOccurrence {
+	var occurrences []*domain.ShiftOccurrence
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, r.loc)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, r.loc)
+	for !day.After(last) {
+		occurrences = append(occurrences, r.occurrencesOn(day.Year(), day.Month(), day.Day())...)
+		day = day.AddDate(0, 0, 1)
+	}
+	return occurrences
+}
+
+// shiftAt returns the shift the schedule has the member working at the
+// instant: that of the instance the instant belongs to or, for a single
+// shift, the shift even on its days off. It returns nil for a rotation's day
+// off and for a nil schedule.
+func shiftAt(schedule *domain.Schedule, at time.Time) (*domain.Shift, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+	if schedule.Rotation == nil {
+		return schedule.Shift, nil
+	}
+	resolver, err := newRotationResolver(schedule.Rotation)
+	if err != nil {
+		return nil, err
+	}
+	if occ := resolver.resolve(at); occ != nil {
+		return occ.Shift, nil
+	}
+	return nil, nil
+}
+
+// shiftApplied names the schedule on an attendance record: the shift of the
+// instance, or outside any instance the shift or rotation.
+func shiftApplied(schedule *domain.Schedule, occ *domain.ShiftOccurrence) string {
+	switch {
+	case occ != nil:
+		return occ.Shift.Name
+	case schedule.Rotation != nil:
+		return schedule.Rotation.Name
+	default:
+		return schedule.Shift.Name
+	}
+}
+
+// evaluateCheckIn resolves the shift instance for a check-in and determines
+// its attendance status against the grace period of the instance's shift.
+func evaluateCheckIn(schedule *domain.Schedule, at time.Time) (occ *domain.ShiftOccurrence, status string, lateMinutes int, err error) {
+	resolver, err := newScheduleResolver(schedule)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	occ = resolver.resolve(at)
+	if occ == nil {
+		return nil, domain.AttendanceStatusNonWorkingDay, 0, nil
+	}
+
+	late := at.Sub(occ.Start)
+	if late > time.Duration(occ.Shift.AllowedLateMinutes)*time.Minute {
+		return occ, domain.AttendanceStatusLate, int(late.Minutes()), nil
+	}
+	return occ, domain.AttendanceStatusPresent, 0, nil
+}
+
+// evaluateCheckOut records the check-out time of a session and, when it is
+// attributed to a shift instance, its overtime and early-leave status against
+// the shift's tolerance.
+func evaluateCheckOut(att *domain.Attendance, at time.Time, allowedEarlyLeaveMinutes int) {
+	att.CheckOutTime = &at
+	att.OvertimeMinutes = 0
+	att.EarlyLeaveMinutes = 0
+	att.CheckOutStatus = ""
+	if att.ScheduledEnd == nil {
+		return
+	}
+
+	end := *att.ScheduledEnd
+	if at.After(end) {
+		att.OvertimeMinutes = int(at.Sub(end).Minutes())
+	}
+	att.CheckOutStatus = domain.CheckOutStatusOnTime
+	if early := end.Sub(at); early > time.Duration(allowedEarlyLeaveMinutes)*time.Minute {
+		att.CheckOutStatus = domain.CheckOutStatusEarlyLeave
+		att.EarlyLeaveMinutes = int(early.Minutes())
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+)
+
+// Europe/Berlin springs forward at 02:00 on 2026-03-29 and falls back at
+// 03:00 on 2026-10-25.
+
+func TestShiftResolverOccurrencesOn(t *testing.T) {
+	tests := []struct {
+		name          string
+		shift         *domain.Shift
+		date          time.Time
+		expectedStart []time.Time
+		expectedEnd   []time.Time
+	}{
+		{
+			name:          "Day Shift On Spring Forward Day",
+			shift:         berlinShift("09:00", "17:00"),
+			date:          time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC),
+			expectedStart: []time.Time{time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC)},
+			expectedEnd:   []time.Time{time.Date(2026, 3, 29, 15, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:          "Day Shift On Fall Back Day",
+			shift:         berlinShift("09:00", "17:00"),
+			date:          time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+			expectedStart: []time.Time{time.Date(2026, 10, 25, 8, 0, 0, 0, time.UTC)},
+			expectedEnd:   []time.Time{time.Date(2026, 10, 25, 16, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:          "Overnight Shift Into Spring Forward",
+			shift:         berlinShift("22:00", "06:00"),
+			date:          time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC),
+			expectedStart: []time.Time{time.Date(2026, 3, 28, 21, 0, 0, 0, time.UTC)},
+			expectedEnd:   []time.Time{time.Date(2026, 3, 29, 4, 0, 0, 0, time.UTC)}, // 7 hours
+		},
+		{
+			name:          "Overnight Shift Into Fall Back",
+			shift:         berlinShift("22:00", "06:00"),
+			date:          time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+			expectedStart: []time.Time{time.Date(2026, 10, 24, 20, 0, 0, 0, time.UTC)},
+			expectedEnd:   []time.Time{time.Date(2026, 10, 25, 5, 0, 0, 0, time.UTC)}, // 9 hours
+		},
+		{
+			name: "Split Shift Past Midnight On Fall Back Day",
+			shift: func() *domain.Shift {
+				shift := berlinShift("", "")
+				shift.Segments = []domain.ShiftSegment{{StartTime: "18:00", EndTime: "22:00"}, {StartTime: "00:00", EndTime: "04:00"}}
+				return shift
+			}(),
+			date: time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+			expectedStart: []time.Time{
+				time.Date(2026, 10, 24, 16, 0, 0, 0, time.UTC),
+				time.Date(2026, 10, 24, 22, 0, 0, 0, time.UTC),
+			},
+			expectedEnd: []time.Time{
+				time.Date(2026, 10, 24, 20, 0, 0, 0, time.UTC),
+				time.Date(2026, 10, 25, 3, 0, 0, 0, time.UTC), // 5 hours
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newShiftResolver(tt.shift)
+			if err != nil {
+				t.Skipf("timezone data unavailable: %v", err)
+			}
+
+			occurrences := r.occurrencesOn(tt.date.Year(), tt.date.Month(), tt.date.Day())
+			if !assert.Len(t, occurrences, len(tt.expectedStart)) {
+				return
+			}
+			for i, occ := range occurrences {
+				assert.Equal(t, tt.date.Format(dateLayout), occ.Date)
+				assert.True(t, tt.expectedStart[i].Equal(occ.Start), "start %s, want %s", occ.Start, tt.expectedStart[i])
+				assert.True(t, tt.expectedEnd[i].Equal(occ.End), "end %s, want %s", occ.End, tt.expectedEnd[i])
+			}
+		})
+	}
+}
+
+func TestShiftResolverResolve(t *testing.T) {
+	dayShift := berlinShift("09:00", "17:00")
+	weekdayShift := berlinShift("09:00", "17:00")
+	weekdayShift.WorkingDays = []string{"MON", "TUE", "WED", "THU", "FRI"}
+	splitShift := berlinShift("", "")
+	splitShift.Segments = []domain.ShiftSegment{{StartTime: "07:00", EndTime: "11:00"}, {StartTime: "16:00", EndTime: "20:00"}}
+
+	tests := []struct {
+		name            string
+		shift           *domain.Shift
+		at              time.Time
+		expectedDate    string // Empty for a day off
+		expectedSegment int
+	}{
+		// 17:00 CET to 09:00 CEST is 15 hours, so the midpoint is 23:30 UTC
+		{name: "Before Midpoint Into Spring Forward", shift: dayShift, at: time.Date(2026, 3, 28, 23, 29, 0, 0, time.UTC), expectedDate: "2026-03-28"},
+		{name: "At Midpoint Into Spring Forward", shift: dayShift, at: time.Date(2026, 3, 28, 23, 30, 0, 0, time.UTC), expectedDate: "2026-03-29"},
+		// 17:00 CEST to 09:00 CET is 17 hours, so the midpoint is again 23:30 UTC
+		{name: "Before Midpoint Into Fall Back", shift: dayShift, at: time.Date(2026, 10, 24, 23, 29, 0, 0, time.UTC), expectedDate: "2026-10-24"},
+		{name: "At Midpoint Into Fall Back", shift: dayShift, at: time.Date(2026, 10, 24, 23, 30, 0, 0, time.UTC), expectedDate: "2026-10-25"},
+		{name: "Early Arrival", shift: dayShift, at: time.Date(2026, 10, 25, 7, 0, 0, 0, time.UTC), expectedDate: "2026-10-25"},
+		{name: "Day Off", shift: weekdayShift, at: time.Date(2026, 3, 28, 11, 0, 0, 0, time.UTC)}, // Saturday
+		{name: "Late On Friday Night", shift: weekdayShift, at: time.Date(2026, 3, 27, 23, 59, 0, 0, time.UTC), expectedDate: "2026-03-27"},
+		{name: "First Segment", shift: splitShift, at: time.Date(2026, 3, 29, 8, 59, 0, 0, time.UTC), expectedDate: "2026-03-29", expectedSegment: 1},
+		// The gap between segments belongs to the second, which can still be checked in to
+		{name: "Gap Between Segments", shift: splitShift, at: time.Date(2026, 3, 29, 9, 0, 0, 0, time.UTC), expectedDate: "2026-03-29", expectedSegment: 2},
+		// 20:00 CEST to 07:00 CEST is 11 hours, so the midpoint is 01:30 local
+		{name: "Before Midpoint After Split Shift", shift: splitShift, at: time.Date(2026, 3, 30, 1, 29, 0, 0, time.FixedZone("CEST", 2*60*60)), expectedDate: "2026-03-29", expectedSegment: 2},
+		{name: "At Midpoint After Split Shift", shift: splitShift, at: time.Date(2026, 3, 30, 1, 30, 0, 0, time.FixedZone("CEST", 2*60*60)), expectedDate: "2026-03-30", expectedSegment: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newShiftResolver(tt.shift)
+			if err != nil {
+				t.Skipf("timezone data unavailable: %v", err)
+			}
+
+			occ := r.resolve(tt.at)
+			if tt.expectedDate == "" {
+				assert.Nil(t, occ)
+				return
+			}
+			if assert.NotNil(t, occ) {
+				assert.Equal(t, tt.expectedDate, occ.Date)
+				assert.Equal(t, tt.expectedSegment, occ.Segment)
+			}
+		})
+	}
+}
+
+func TestRotationResolverAcrossDST(t *testing.T) {
+	shift := berlinShift("09:00", "17:00")
+	rotation := &domain.Rotation{
+		AnchorDate: "2026-03-28",
+		Days:       []*string{&shift.ID, nil}, // Alternate days on
+		Timezone:   "Europe/Berlin",
+		Shifts:     map[string]*domain.Shift{shift.ID: shift},
+	}
+	r, err := newRotationResolver(rotation)
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// The cycle counts calendar days, so the 23-hour day does not shift it
+	for _, date := range []string{"2026-03-28", "2026-03-30", "2026-10-24", "2026-10-26"} {
+		day, _ := time.Parse(dateLayout, date)
+		assert.Len(t, r.occurrencesOn(day.Year(), day.Month(), day.Day()), 1, date)
+		next := day.AddDate(0, 0, 1)
+		assert.Empty(t, r.occurrencesOn(next.Year(), next.Month(), next.Day()), next.Format(dateLayout))
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/core/port"
+)
+
+// syncClockTolerance allows for events stamped slightly ahead of the server
+// clock after offset correction.
+const syncClockTolerance = time.Minute
+
+type SyncService struct {
+	repo    port.SyncRepository
+	attSvc  *AttendanceService
+	txMgr   port.TransactionManager
+	maxAge  time.Duration
+	maxSkew time.Duration
+}
+
+// NewSyncService creates a service that applies offline events through
+// attSvc. Events captured more than maxAge before the sync, or from a device
+// whose clock is off by more than maxSkew, are flagged for review.
+func NewSyncService(repo port.SyncRepository, attSvc *AttendanceService, txMgr port.TransactionManager, maxAge, maxSkew time.Duration) *SyncService {
+	return &SyncService{repo: repo, attSvc: attSvc, txMgr: txMgr, maxAge: maxAge, maxSkew: maxSkew}
+}
+
+// SyncEvents applies the user's queued offline events in order and returns
+// one result per event. A rejected event does not stop the ones after it.
+// Events already synced return their original result.
+func (s *SyncService) SyncEvents(ctx context.Context, userID string, events []domain.SyncEvent) ([]*domain.OfflineEvent, error) {
+	now := time.Now()
+	results := make([]*domain.OfflineEvent, 0, len(events))
+	var previous time.Time
+	for _, ev := range events {
+		result, err := s.syncEvent(ctx, userID, ev, previous, now)
+		if err != nil {
+			return nil, err
+		}
+		previous = result.EventTime
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (s *SyncService) syncEvent(ctx context.Context, userID string, ev domain.SyncEvent, previous, now time.Time) (*domain.OfflineEvent, error) {
+	existing, err := s.syncedEvent(ctx, userID, ev.ClientEventID)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	offset := time.Duration(ev.ClockOffsetSeconds) * time.Second
+	event := &domain.OfflineEvent{
+		UserID:             userID,
+		ClientEventID:      ev.ClientEventID,
+		Type:               ev.Type,
+		OrgID:              ev.OrganizationID,
+		CapturedAt:         ev.CapturedAt,
+		ClockOffsetSeconds: ev.ClockOffsetSeconds,
+		EventTime:          ev.CapturedAt.Add(-offset),
+		ReceivedAt:         now,
+	}
+
+	switch {
+	case event.EventTime.After(now.Add(syncClockTolerance)):
+		return rejectEvent(event, errors.New("event time cannot be in the future")), nil
+	case event.EventTime.Before(previous):
+		return rejectEvent(event, errors.New("events must be in chronological order")), nil
+	}
+
+	var flags []string
+	if offset > s.maxSkew || offset < -s.maxSkew {
+		flags = append(flags, fmt.Sprintf("device clock off by %s", offset))
+	}
+	if age := now.Sub(event.EventTime); age > s.maxAge {
+		flags = append(flags, fmt.Sprintf("captured offline %s before sync", age.Round(time.Minute)))
+	}
+
+	// The event is recorded in the same transaction as the attendance change,
+	// so a concurrent sync of the same event cannot apply it twice.
+	var applyErr error
+	err = s.txMgr.RunInTx(ctx, func(ctx context.Context) error {
+		att, err := s.apply(ctx, userID, ev, event.EventTime, flags)
+		if err != nil {
+			applyErr = err
+			return err
+		}
+		event.OrgID = att.OrgID
+		event.AttendanceID = &att.ID
+		event.Status = domain.SyncStatusAccepted
+		event.Flagged = att.Flagged
+		event.FlagReason = att.FlagReason
+		return s.repo.CreateOfflineEvent(ctx, event)
+	})
+	if applyErr != nil {
+		// Violations are written only now, as the rollback discarded anything
+		// written in the transaction
+		if err := s.attSvc.recordViolations(ctx, applyErr); err != nil {
+			return nil, err
+		}
+		return rejectEvent(event, applyErr), nil
+	}
+	var dupErr *domain.DuplicateError
+	if errors.As(err, &dupErr) {
+		return s.syncedEvent(ctx, userID, ev.ClientEventID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// apply performs the event's check-in or check-out at the given time.
+func (s *SyncService) apply(ctx context.Context, userID string, ev domain.SyncEvent, at time.Time, flags []string) (*domain.Attendance, error) {
+	if ev.Type == domain.SyncEventCheckOut {
+		req := &domain.CheckOutRequest{
+			OrganizationID: ev.OrganizationID,
+			Latitude:       ev.Latitude,
+			Longitude:      ev.Longitude,
+			Note:           ev.Note,
+		}
+		return s.attSvc.checkOutAt(ctx, userID, req, checkOutOptions{at: at, offline: true, flags: flags})
+	}
+
+	att := &domain.Attendance{
+		TaskID:           ev.TaskID,
+		CheckInTime:      at,
+		LocationLat:      *ev.Latitude,
+		LocationLong:     *ev.Longitude,
+		LocationAccuracy: ev.Accuracy,
+		LocationProvider: ev.Provider,
+		MockLocation:     ev.MockLocation,
+		Note:             ev.Note,
+		Offline:          true,
+	}
+	for _, reason := range flags {
+		att.Flag(reason)
+	}
+	return s.attSvc.checkIn(ctx, userID, ev.OrganizationID, att, "")
+}
+
+// syncedEvent returns the stored result of an event synced before, or nil.
+func (s *SyncService) syncedEvent(ctx context.Context, userID, clientEventID string) (*domain.OfflineEvent, error) {
+	event, err := s.repo.GetOfflineEvent(ctx, userID, clientEventID)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	event.Duplicate = true
+	return event, nil
+}
+
+func rejectEvent(event *domain.OfflineEvent, err error) *domain.OfflineEvent {
+	event.Status = domain.SyncStatusRejected
+	event.Error = err.Error()
+	return event
+}
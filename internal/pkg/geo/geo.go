@@ -0,0 +1,24 @@
+package geo
+
+import "math"
+
+// EarthRadiusMeters is the mean Earth radius used for great-circle calculations.
+const EarthRadiusMeters = 6371008.8
+
+// Distance returns the great-circle distance in meters between two points
+// given in decimal degrees, using the haversine formula.
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := toRadians(lat1)
+	phi2 := toRadians(lat2)
+	dPhi := toRadians(lat2 - lat1)
+	dLambda := toRadians(lon2 - lon1)
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return EarthRadiusMeters * c
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
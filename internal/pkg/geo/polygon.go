@@ -0,0 +1,202 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MaxPositions caps the number of positions in a boundary, keeping the
+// self-intersection check cheap.
+const MaxPositions = 1000
+
+// Position is a point as GeoJSON orders it: longitude, then latitude.
+type Position [2]float64
+
+// Polygon is a list of closed rings. The first ring is the exterior; any
+// further rings are holes.
+type Polygon [][]Position
+
+// MultiPolygon is an area made of one or more polygons.
+type MultiPolygon []Polygon
+
+// ParseBoundary reads the coordinates of a GeoJSON Polygon or MultiPolygon
+// geometry and validates its rings: each must be closed, have at least four
+// positions and not intersect itself.
+func ParseBoundary(geometryType string, coordinates json.RawMessage) (MultiPolygon, error) {
+	var raw [][][][]float64
+	switch geometryType {
+	case "Polygon":
+		var polygon [][][]float64
+		if err := json.Unmarshal(coordinates, &polygon); err != nil {
+			return nil, errors.New("coordinates must be an array of rings")
+		}
+		raw = [][][][]float64{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(coordinates, &raw); err != nil {
+			return nil, errors.New("coordinates must be an array of polygons")
+		}
+	default:
+		return nil, errors.New("type must be Polygon or MultiPolygon")
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("boundary has no polygons")
+	}
+
+	count := 0
+	area := make(MultiPolygon, 0, len(raw))
+	for _, rawPolygon := range raw {
+		if len(rawPolygon) == 0 {
+			return nil, errors.New("polygon has no rings")
+		}
+		polygon := make(Polygon, 0, len(rawPolygon))
+		for _, rawRing := range rawPolygon {
+			count += len(rawRing)
+			if count > MaxPositions {
+				return nil, fmt.Errorf("boundary has more than %d positions", MaxPositions)
+			}
+			ring, err := parseRing(rawRing)
+			if err != nil {
+				return nil, err
+			}
+			polygon = append(polygon, ring)
+		}
+		area = append(area, polygon)
+	}
+	return area, nil
+}
+
+func parseRing(raw [][]float64) ([]Position, error) {
+	if len(raw) < 4 {
+		return nil, errors.New("ring must have at least 4 positions")
+	}
+	ring := make([]Position, len(raw))
+	for i, p := range raw {
+		if len(p) < 2 {
+			return nil, errors.New("position must have a longitude and a latitude")
+		}
+		if p[0] < -180 || p[0] > 180 || p[1] < -90 || p[1] > 90 {
+			return nil, errors.New("position is out of range")
+		}
+		ring[i] = Position{p[0], p[1]}
+	}
+	if ring[0] != ring[len(ring)-1] {
+		return nil, errors.New("ring is not closed")
+	}
+	if selfIntersects(ring) {
+		return nil, errors.New("ring intersects itself")
+	}
+	return ring, nil
+}
+
+// selfIntersects reports whether any two non-adjacent edges of the closed
+// ring touch or cross.
+func selfIntersects(ring []Position) bool {
+	edges := len(ring) - 1
+	for i := 0; i < edges; i++ {
+		for j := i + 1; j < edges; j++ {
+			if j == i+1 || (i == 0 && j == edges-1) {
+				continue // Adjacent edges share an endpoint
+			}
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func segmentsIntersect(p1, p2, p3, p4 Position) bool {
+	d1 := orientation(p3, p4, p1)
+	d2 := orientation(p3, p4, p2)
+	d3 := orientation(p1, p2, p3)
+	d4 := orientation(p1, p2, p4)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(p3, p4, p1)) || (d2 == 0 && onSegment(p3, p4, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, p3)) || (d4 == 0 && onSegment(p1, p2, p4))
+}
+
+// orientation is positive when c is left of the line from a to b, negative
+// when right and zero when the three are collinear.
+func orientation(a, b, c Position) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// onSegment reports whether c, collinear with a and b, lies between them.
+func onSegment(a, b, c Position) bool {
+	return math.Min(a[0], b[0]) <= c[0] && c[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= c[1] && c[1] <= math.Max(a[1], b[1])
+}
+
+// Contains reports whether the point is inside one of the polygons and not in
+// any of its holes.
+func (m MultiPolygon) Contains(lat, lon float64) bool {
+	for _, polygon := range m {
+		if !ringContains(polygon[0], lat, lon) {
+			continue
+		}
+		inHole := false
+		for _, hole := range polygon[1:] {
+			if ringContains(hole, lat, lon) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// ringContains casts a ray from the point and counts the edges it crosses.
+func ringContains(ring []Position, lat, lon float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > lat) != (b[1] > lat) && lon < (b[0]-a[0])*(lat-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceOutside returns how far in meters the point is from the area: zero
+// inside it, otherwise the distance to the nearest edge. Edges are measured on
+// a plane tangent at the point, which is accurate for site-sized areas.
+func (m MultiPolygon) DistanceOutside(lat, lon float64) float64 {
+	if m.Contains(lat, lon) {
+		return 0
+	}
+	scaleX := EarthRadiusMeters * toRadians(1) * math.Cos(toRadians(lat))
+	scaleY := EarthRadiusMeters * toRadians(1)
+	project := func(p Position) (float64, float64) {
+		return (p[0] - lon) * scaleX, (p[1] - lat) * scaleY
+	}
+
+	nearest := math.Inf(1)
+	for _, polygon := range m {
+		for _, ring := range polygon {
+			for i := 0; i < len(ring)-1; i++ {
+				ax, ay := project(ring[i])
+				bx, by := project(ring[i+1])
+				nearest = math.Min(nearest, distanceToSegment(ax, ay, bx, by))
+			}
+		}
+	}
+	return nearest
+}
+
+// distanceToSegment returns the distance from the origin to the segment
+// between a and b.
+func distanceToSegment(ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if lengthSq := dx*dx + dy*dy; lengthSq > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lengthSq))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
@@ -0,0 +1,123 @@
+package geo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// squareWithHole is a site about 1.1km across with a hole about 220m across
+// in its middle.
+const squareWithHole = `[
+	[[0, 0], [0.01, 0], [0.01, 0.01], [0, 0.01], [0, 0]],
+	[[0.004, 0.004], [0.006, 0.004], [0.006, 0.006], [0.004, 0.006], [0.004, 0.004]]
+]`
+
+func TestParseBoundary(t *testing.T) {
+	tests := []struct {
+		name          string
+		geometryType  string
+		coordinates   string
+		expectedError string
+		expectedRings []int
+	}{
+		{
+			name:          "Polygon With Hole",
+			geometryType:  "Polygon",
+			coordinates:   squareWithHole,
+			expectedRings: []int{2},
+		},
+		{
+			name:          "MultiPolygon",
+			geometryType:  "MultiPolygon",
+			coordinates:   `[[[[0, 0], [1, 0], [1, 1], [0, 0]]], [[[2, 2], [3, 2], [3, 3], [2, 2]]]]`,
+			expectedRings: []int{1, 1},
+		},
+		{
+			name:          "Bow-tie Ring",
+			geometryType:  "Polygon",
+			coordinates:   `[[[0, 0], [10, 10], [10, 0], [0, 10], [0, 0]]]`,
+			expectedError: "ring intersects itself",
+		},
+		{
+			name:          "Ring Touching Itself",
+			geometryType:  "Polygon",
+			coordinates:   `[[[0, 0], [10, 0], [5, 5], [10, 10], [0, 10], [5, 5], [0, 0]]]`,
+			expectedError: "ring intersects itself",
+		},
+		{
+			name:          "Unclosed Ring",
+			geometryType:  "Polygon",
+			coordinates:   `[[[0, 0], [10, 0], [10, 10], [0, 10]]]`,
+			expectedError: "ring is not closed",
+		},
+		{
+			name:          "Too Few Positions",
+			geometryType:  "Polygon",
+			coordinates:   `[[[0, 0], [10, 0], [0, 0]]]`,
+			expectedError: "ring must have at least 4 positions",
+		},
+		{
+			name:          "Position Out Of Range",
+			geometryType:  "Polygon",
+			coordinates:   `[[[0, 0], [181, 0], [10, 10], [0, 0]]]`,
+			expectedError: "position is out of range",
+		},
+		{
+			name:          "Polygon Without Rings",
+			geometryType:  "MultiPolygon",
+			coordinates:   `[[]]`,
+			expectedError: "polygon has no rings",
+		},
+		{
+			name:          "Point Geometry",
+			geometryType:  "Point",
+			coordinates:   `[0, 0]`,
+			expectedError: "type must be Polygon or MultiPolygon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			area, err := ParseBoundary(tt.geometryType, json.RawMessage(tt.coordinates))
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			if !assert.NoError(t, err) || !assert.Len(t, area, len(tt.expectedRings)) {
+				return
+			}
+			for i, rings := range tt.expectedRings {
+				assert.Len(t, area[i], rings)
+			}
+		})
+	}
+}
+
+func TestMultiPolygonDistanceOutside(t *testing.T) {
+	area, err := ParseBoundary("Polygon", json.RawMessage(squareWithHole))
+	if !assert.NoError(t, err) {
+		return
+	}
+	metersPerDegree := EarthRadiusMeters * toRadians(1)
+
+	tests := []struct {
+		name             string
+		lat, lon         float64
+		expectedContains bool
+		expectedDistance float64
+	}{
+		{name: "Inside", lat: 0.002, lon: 0.002, expectedContains: true},
+		{name: "In Hole", lat: 0.005, lon: 0.005, expectedDistance: 0.001 * metersPerDegree},
+		{name: "North Of Site", lat: 0.011, lon: 0.005, expectedDistance: 0.001 * metersPerDegree},
+		{name: "Past A Corner", lat: -0.003, lon: 0.014, expectedDistance: 0.005 * metersPerDegree},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedContains, area.Contains(tt.lat, tt.lon))
+			assert.InDelta(t, tt.expectedDistance, area.DistanceOutside(tt.lat, tt.lon), 0.5)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package qrtoken signs and verifies the rotating tokens shown as QR codes at
+// checkpoints. A token has the form <checkpoint_id>.<window>.<signature>,
+// where window counts rotation periods since the Unix epoch and signature is
+// the base64url HMAC-SHA256 of "<checkpoint_id>.<window>" under the
+// checkpoint's secret.
+package qrtoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrMalformed = errors.New("malformed token")
+
+// Window returns the rotation window t falls in.
+func Window(t time.Time, rotation time.Duration) int64 {
+	return t.Unix() / int64(rotation/time.Second)
+}
+
+// WindowEnd returns the time the window ends and the next token is shown.
+func WindowEnd(window int64, rotation time.Duration) time.Time {
+	return time.Unix((window+1)*int64(rotation/time.Second), 0)
+}
+
+// Sign returns the token for the checkpoint and window.
+func Sign(secret []byte, checkpointID string, window int64) string {
+	payload := checkpointID + "." + strconv.FormatInt(window, 10)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signature(secret, payload))
+}
+
+// Parse splits a token into its checkpoint ID and window without checking
+// the signature, so the caller can look up the checkpoint's secret.
+func Parse(token string) (checkpointID string, window int64, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || !isUUID(parts[0]) {
+		return "", 0, ErrMalformed
+	}
+	window, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, ErrMalformed
+	}
+	return parts[0], window, nil
+}
+
+// Verify reports whether the token was signed with the secret.
+func Verify(secret []byte, token string) bool {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return false
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(sig, signature(secret, token[:i]))
+}
+
+func signature(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// isUUID reports whether s is a UUID in its canonical hyphenated form.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package qrtoken
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const checkpointID = "123e4567-e89b-12d3-a456-426614174000"
+
+func TestWindow(t *testing.T) {
+	rotation := 30 * time.Second
+	start := time.Unix(1_800_000_000, 0) // A multiple of 30 seconds
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected int64
+	}{
+		{name: "Window Start", at: start, expected: 60_000_000},
+		{name: "Within Window", at: start.Add(29 * time.Second), expected: 60_000_000},
+		{name: "Next Window", at: start.Add(30 * time.Second), expected: 60_000_001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := Window(tt.at, rotation)
+			assert.Equal(t, tt.expected, window)
+			assert.True(t, WindowEnd(window, rotation).After(tt.at))
+			assert.False(t, WindowEnd(window-1, rotation).After(tt.at))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		expectedWindow int64
+		expectedError  error
+	}{
+		{name: "Signed Token", token: Sign([]byte("secret"), checkpointID, 42), expectedWindow: 42},
+		{name: "Missing Signature", token: checkpointID + ".42", expectedError: ErrMalformed},
+		{name: "Extra Part", token: Sign([]byte("secret"), checkpointID, 42) + ".x", expectedError: ErrMalformed},
+		{name: "Checkpoint Not A UUID", token: Sign([]byte("secret"), "checkpoint-1", 42), expectedError: ErrMalformed},
+		{name: "Window Not A Number", token: checkpointID + ".forty-two.c2ln", expectedError: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, window, err := Parse(tt.token)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, checkpointID, id)
+			assert.Equal(t, tt.expectedWindow, window)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	secret := []byte("checkpoint-secret")
+	token := Sign(secret, checkpointID, 42)
+	parts := strings.Split(token, ".")
+	// Swap the first character for another base64url one, so the signature
+	// still decodes but no longer matches
+	sig := []byte(parts[2])
+	if sig[0] == 'A' {
+		sig[0] = 'B'
+	} else {
+		sig[0] = 'A'
+	}
+
+	tests := []struct {
+		name     string
+		secret   []byte
+		token    string
+		expected bool
+	}{
+		{name: "Valid", secret: secret, token: token, expected: true},
+		{name: "Wrong Secret", secret: []byte("other-secret"), token: token},
+		{name: "Tampered Window", secret: secret, token: parts[0] + ".43." + parts[2]},
+		{name: "Tampered Checkpoint", secret: secret, token: "00000000-0000-0000-0000-000000000000.42." + parts[2]},
+		{name: "Tampered Signature", secret: secret, token: parts[0] + "." + parts[1] + "." + string(sig)},
+		{name: "Signature Not Base64", secret: secret, token: parts[0] + "." + parts[1] + ".!!"},
+		{name: "No Separator", secret: secret, token: "token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Verify(tt.secret, tt.token))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/syst3mctl/check-in-api/internal/pkg/logger"
+)
+
+// Every runs job immediately and then once per interval until ctx is
+// cancelled. Errors are logged and do not stop the schedule.
+func Every(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil && ctx.Err() == nil {
+			logger.Error("Scheduled job failed", "job", name, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/syst3mctl/check-in-api/internal/core/domain"
+	"github.com/syst3mctl/check-in-api/internal/pkg/geo"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -22,6 +23,27 @@ func Init() {
 		}
 		return name
 	})
+	validate.RegisterStructValidation(validateBoundary, domain.Boundary{})
+	validate.RegisterStructValidation(validateTask, domain.Task{})
+}
+
+// validateBoundary checks that the geometry's rings form valid polygons.
+func validateBoundary(sl validator.StructLevel) {
+	b := sl.Current().Interface().(domain.Boundary)
+	if b.Type == "" || len(b.Coordinates) == 0 {
+		return // Reported by the field tags
+	}
+	if _, err := geo.ParseBoundary(b.Type, b.Coordinates); err != nil {
+		sl.ReportError(b.Coordinates, "coordinates", "Coordinates", "boundary", err.Error())
+	}
+}
+
+// validateTask requires a radius for a geofenced task without a boundary.
+func validateTask(sl validator.StructLevel) {
+	t := sl.Current().Interface().(domain.Task)
+	if t.GeofencingEnabled && t.Boundary == nil && t.RadiusMeters == 0 {
+		sl.ReportError(t.RadiusMeters, "radius_meters", "RadiusMeters", "required_if", "GeofencingEnabled true")
+	}
 }
 
 func ValidateStruct(s interface{}) *domain.ErrorResponse {
@@ -39,7 +61,7 @@ func ValidateStruct(s interface{}) *domain.ErrorResponse {
 		field := err.Field()
 		var msg string
 		switch err.Tag() {
-		case "required":
+		case "required", "required_if", "required_with", "required_without":
 			msg = "field is required"
 		case "email":
 			msg = "email is invalid format"
@@ -47,6 +69,18 @@ func ValidateStruct(s interface{}) *domain.ErrorResponse {
 			msg = fmt.Sprintf("must be at least %s characters", err.Param())
 		case "max":
 			msg = fmt.Sprintf("must be at most %s characters", err.Param())
+		case "gte":
+			msg = fmt.Sprintf("must be greater than or equal to %s", err.Param())
+		case "datetime":
+			msg = fmt.Sprintf("must match the format %s", err.Param())
+		case "timezone":
+			msg = "must be a valid IANA timezone"
+		case "unique":
+			msg = "must not contain duplicates"
+		case "numeric":
+			msg = "must contain only digits"
+		case "boundary":
+			msg = fmt.Sprintf("must be a valid GeoJSON Polygon or MultiPolygon: %s", err.Param())
 		case "oneof":
 			msg = fmt.Sprintf("must be one of: %s", strings.ReplaceAll(err.Param(), " ", ", "))
 		default:
@@ -60,3 +94,12 @@ func ValidateStruct(s interface{}) *domain.ErrorResponse {
 		Errors:  validationErrors,
 	}
 }
+
+// IsValid reports whether a single value satisfies the given validation tag,
+// e.g. IsValid(id, "uuid").
+func IsValid(value interface{}, tag string) bool {
+	if validate == nil {
+		Init()
+	}
+	return validate.Var(value, tag) == nil
+}
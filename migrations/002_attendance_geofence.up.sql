@@ -0,0 +1 @@
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS distance_meters DOUBLE PRECISION; -- Distance from the geofence center at check-in
@@ -0,0 +1,5 @@
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS geofence_radius_meters INT NOT NULL DEFAULT 0;
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS geofence_mode VARCHAR(20) NOT NULL DEFAULT 'OFF'; -- 'OFF', 'WARN', 'REJECT'
+
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS flagged BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS flag_reason TEXT;
@@ -0,0 +1,3 @@
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS late_minutes INT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN attendance.status IS 'PRESENT, LATE, ABSENT, NON_WORKING_DAY';
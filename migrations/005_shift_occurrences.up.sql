@@ -0,0 +1,7 @@
+-- Snapshot of the shift instance a check-in was attributed to
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS shift_date DATE;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS scheduled_start TIMESTAMP WITH TIME ZONE;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS scheduled_end TIMESTAMP WITH TIME ZONE;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS overtime_minutes INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_attendance_user_shift_date ON attendance(user_id, shift_date);
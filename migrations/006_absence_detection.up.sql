@@ -0,0 +1,2 @@
+-- At most one ABSENT record per member and shift date
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attendance_absent_unique ON attendance(user_id, org_id, shift_date) WHERE status = 'ABSENT';
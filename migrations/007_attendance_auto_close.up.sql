@@ -0,0 +1,3 @@
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS auto_closed BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_attendance_open_sessions ON attendance(check_in_time) WHERE check_out_time IS NULL;
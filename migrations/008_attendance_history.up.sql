@@ -0,0 +1,2 @@
+-- Keyset pagination for attendance history
+CREATE INDEX IF NOT EXISTS idx_attendance_user_check_in ON attendance(user_id, check_in_time DESC, id DESC);
@@ -0,0 +1,2 @@
+-- Keyset pagination for organization-wide attendance views
+CREATE INDEX IF NOT EXISTS idx_attendance_org_check_in ON attendance(org_id, check_in_time DESC, id DESC);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS attendance_corrections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    attendance_id UUID NOT NULL REFERENCES attendance(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    requested_check_in_time TIMESTAMP WITH TIME ZONE,
+    requested_check_out_time TIMESTAMP WITH TIME ZONE,
+    original_check_in_time TIMESTAMP WITH TIME ZONE NOT NULL, -- Attendance values before the change, for audit
+    original_check_out_time TIMESTAMP WITH TIME ZONE,
+    original_status VARCHAR(50) NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'PENDING', -- 'PENDING', 'APPROVED', 'REJECTED'
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one pending correction per attendance record
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attendance_corrections_pending ON attendance_corrections(attendance_id) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_attendance_corrections_org ON attendance_corrections(org_id, status, created_at DESC);
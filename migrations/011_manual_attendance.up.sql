@@ -0,0 +1,2 @@
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'DEVICE'; -- 'DEVICE', 'MANUAL'
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS recorded_by UUID REFERENCES users(id) ON DELETE SET NULL; -- Owner/manager who entered a MANUAL record
@@ -0,0 +1,15 @@
+ALTER TABLE shifts ADD COLUMN IF NOT EXISTS break_types JSONB NOT NULL DEFAULT '[]'; -- [{"name": "LUNCH", "paid": false}, ...]
+
+CREATE TABLE IF NOT EXISTS attendance_breaks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    attendance_id UUID NOT NULL REFERENCES attendance(id) ON DELETE CASCADE,
+    break_type VARCHAR(50) NOT NULL,
+    paid BOOLEAN NOT NULL DEFAULT FALSE, -- Snapshot of the shift's break type
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_breaks_attendance ON attendance_breaks(attendance_id);
+-- At most one running break per session
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attendance_breaks_open ON attendance_breaks(attendance_id) WHERE end_time IS NULL;
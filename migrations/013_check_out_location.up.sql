@@ -0,0 +1,8 @@
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS check_out_lat DOUBLE PRECISION;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS check_out_long DOUBLE PRECISION;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS check_out_distance_meters DOUBLE PRECISION; -- Distance from the geofence center at check-out
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS check_out_note TEXT;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS check_out_status VARCHAR(50); -- 'ON_TIME', 'EARLY_LEAVE'
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS early_leave_minutes INT NOT NULL DEFAULT 0;
+
+ALTER TABLE shifts ADD COLUMN IF NOT EXISTS allowed_early_leave_minutes INT NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+-- Close all but the newest open session per member and organization so the
+-- unique index below can be built. Closed rows are marked auto_closed for review.
+UPDATE attendance a
+SET check_out_time = a.check_in_time, auto_closed = TRUE
+WHERE a.check_out_time IS NULL AND a.status <> 'ABSENT'
+  AND EXISTS (
+      SELECT 1 FROM attendance b
+      WHERE b.user_id = a.user_id AND b.org_id = a.org_id
+        AND b.check_out_time IS NULL AND b.status <> 'ABSENT'
+        AND (b.check_in_time, b.id) > (a.check_in_time, a.id)
+  );
+
+-- At most one open session per member and organization
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attendance_open_session ON attendance(user_id, org_id) WHERE check_out_time IS NULL AND status <> 'ABSENT';
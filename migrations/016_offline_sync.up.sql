@@ -0,0 +1,21 @@
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS offline BOOLEAN NOT NULL DEFAULT FALSE; -- Check-in captured on the device without connectivity
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS check_out_offline BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Offline events applied through the sync endpoint, kept so that a retried
+-- sync does not apply an event twice
+CREATE TABLE IF NOT EXISTS offline_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_event_id VARCHAR(100) NOT NULL,
+    type VARCHAR(50) NOT NULL, -- 'CHECK_IN', 'CHECK_OUT'
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    attendance_id UUID REFERENCES attendance(id) ON DELETE SET NULL,
+    captured_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Device clock
+    clock_offset_seconds INT NOT NULL DEFAULT 0, -- Device clock minus server clock
+    event_time TIMESTAMP WITH TIME ZONE NOT NULL, -- captured_at corrected by the clock offset
+    flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    flag_reason TEXT,
+    received_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_offline_events_client_event ON offline_events(user_id, client_event_id);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS checkpoints (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    rotation_seconds INT NOT NULL DEFAULT 30, -- How often the QR token changes
+    secret BYTEA NOT NULL, -- HMAC key for the checkpoint's tokens
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_checkpoints_org ON checkpoints(org_id);
+
+-- Each member may check in with a given checkpoint token only once
+CREATE TABLE IF NOT EXISTS checkpoint_token_uses (
+    checkpoint_id UUID NOT NULL REFERENCES checkpoints(id) ON DELETE CASCADE,
+    time_window BIGINT NOT NULL, -- Rotation window the token was signed for
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    used_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT checkpoint_token_uses_pkey PRIMARY KEY (checkpoint_id, time_window, user_id)
+);
+
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS proof_type VARCHAR(50); -- 'GPS', 'QR'
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS checkpoint_id UUID REFERENCES checkpoints(id) ON DELETE SET NULL;
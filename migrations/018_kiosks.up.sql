@@ -0,0 +1,24 @@
+-- Shared devices that check members in by PIN or badge
+CREATE TABLE IF NOT EXISTS kiosks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL, -- SHA-256 of the device token, which is shown only at registration
+    failed_attempts INT NOT NULL DEFAULT 0, -- Unrecognized PINs or badges since the last lockout or success
+    locked_until TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT kiosks_token_hash_key UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_kiosks_org ON kiosks(org_id);
+
+ALTER TABLE organization_members ADD COLUMN IF NOT EXISTS kiosk_pin_hash VARCHAR(64); -- HMAC-SHA256 of the org ID and PIN
+ALTER TABLE organization_members ADD COLUMN IF NOT EXISTS badge_id VARCHAR(100);
+
+-- A kiosk identifies the member from the PIN or badge alone
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_members_kiosk_pin ON organization_members(org_id, kiosk_pin_hash) WHERE kiosk_pin_hash IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_members_badge ON organization_members(org_id, badge_id) WHERE badge_id IS NOT NULL;
+
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS kiosk_id UUID REFERENCES kiosks(id) ON DELETE SET NULL;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS check_out_kiosk_id UUID REFERENCES kiosks(id) ON DELETE SET NULL;
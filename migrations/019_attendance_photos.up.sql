@@ -0,0 +1,5 @@
+-- Photo evidence attached to a check-in; the file itself is kept in file storage
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS photo_key VARCHAR(255);
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS photo_content_type VARCHAR(50);
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS photo_size_bytes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS photo_uploaded_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,9 @@
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS location_accuracy DOUBLE PRECISION; -- Meters, as reported by the device
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS location_provider VARCHAR(50);
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS mock_location BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS risk_score INT NOT NULL DEFAULT 0; -- 0-100
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS risk_reasons TEXT[];
+
+-- Finds other members checking in at exactly the same coordinates
+CREATE INDEX IF NOT EXISTS idx_attendance_location ON attendance(location_lat, location_long, check_in_time);
+CREATE INDEX IF NOT EXISTS idx_attendance_org_risk ON attendance(org_id, risk_score) WHERE risk_score > 0;
@@ -0,0 +1,20 @@
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS network_mode VARCHAR(20) NOT NULL DEFAULT 'OFF'; -- 'OFF', 'WARN', 'REJECT'
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS allowed_networks TEXT[]; -- CIDR ranges
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS location_rule VARCHAR(10) NOT NULL DEFAULT 'ALL'; -- 'ALL', 'ANY'
+
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS client_ip VARCHAR(45);
+
+-- Check-ins refused by the geofence or network allowlist
+CREATE TABLE IF NOT EXISTS check_in_violations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL, -- 'GEOFENCE', 'NETWORK'
+    client_ip VARCHAR(45),
+    location_lat DOUBLE PRECISION,
+    location_long DOUBLE PRECISION,
+    distance_meters DOUBLE PRECISION,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_check_in_violations_org ON check_in_violations(org_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- GeoJSON Polygon or MultiPolygon outlining an irregular geofence; replaces the radius when set
+ALTER TABLE sites ADD COLUMN IF NOT EXISTS boundary JSONB;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS boundary JSONB;
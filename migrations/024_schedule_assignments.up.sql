@@ -0,0 +1,14 @@
+-- Member-level shifts overriding the group's shift within an effective window.
+-- Dates are in the shift's timezone; past assignments are kept as history
+CREATE TABLE IF NOT EXISTS schedule_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    shift_id UUID NOT NULL REFERENCES shifts(id) ON DELETE CASCADE,
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT schedule_assignments_dates_check CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedule_assignments_member ON schedule_assignments(org_id, user_id, effective_from);
@@ -0,0 +1,27 @@
+-- Repeating cycles of shifts and days off, starting from anchor_date
+CREATE TABLE IF NOT EXISTS rotations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    anchor_date DATE NOT NULL,
+    timezone VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT rotations_org_id_name_key UNIQUE (org_id, name)
+);
+
+-- The shift worked on each day of a rotation's cycle, by 1-based position;
+-- a NULL shift is a day off. A shift cannot be removed while a rotation uses it
+CREATE TABLE IF NOT EXISTS rotation_days (
+    rotation_id UUID NOT NULL REFERENCES rotations(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    shift_id UUID REFERENCES shifts(id),
+    PRIMARY KEY (rotation_id, position)
+);
+
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS rotation_id UUID REFERENCES rotations(id) ON DELETE SET NULL;
+
+-- An assignment is to either a shift or a rotation
+ALTER TABLE schedule_assignments ALTER COLUMN shift_id DROP NOT NULL;
+ALTER TABLE schedule_assignments ADD COLUMN IF NOT EXISTS rotation_id UUID REFERENCES rotations(id) ON DELETE CASCADE;
+ALTER TABLE schedule_assignments DROP CONSTRAINT IF EXISTS schedule_assignments_schedule_check;
+ALTER TABLE schedule_assignments ADD CONSTRAINT schedule_assignments_schedule_check CHECK ((shift_id IS NULL) <> (rotation_id IS NULL));
@@ -0,0 +1,10 @@
+-- Segments of split shifts, e.g. [{"start_time": "07:00", "end_time": "11:00"}, ...];
+-- empty for shifts worked in one stretch from start_time to end_time
+ALTER TABLE shifts ADD COLUMN IF NOT EXISTS segments JSONB NOT NULL DEFAULT '[]';
+
+-- 1-based segment of a split shift a record is matched to, 0 otherwise
+ALTER TABLE attendance ADD COLUMN IF NOT EXISTS shift_segment INT NOT NULL DEFAULT 0;
+
+-- At most one ABSENT record per member and shift segment
+DROP INDEX IF EXISTS idx_attendance_absent_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attendance_absent_unique ON attendance(user_id, org_id, shift_date, shift_segment) WHERE status = 'ABSENT';
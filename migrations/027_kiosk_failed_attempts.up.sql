@@ -0,0 +1,13 @@
+-- Unrecognized PINs and badges, counted per kiosk and source over a sliding
+-- window. A recognized entry does not clear them, so members cannot reset
+-- the lockout with their own PIN.
+CREATE TABLE IF NOT EXISTS kiosk_failed_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kiosk_id UUID NOT NULL REFERENCES kiosks(id) ON DELETE CASCADE,
+    source VARCHAR(10) NOT NULL, -- PIN or BADGE
+    attempted_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_kiosk_failed_attempts_kiosk ON kiosk_failed_attempts(kiosk_id, source, attempted_at);
+
+ALTER TABLE kiosks DROP COLUMN IF EXISTS failed_attempts;